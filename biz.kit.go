@@ -31,6 +31,14 @@ type Database interface {
 	WithTx(ctx context.Context, f WithTxFunc) error
 }
 
+// Databases 为多实例数据库的集合, 实例名称与配置中心 /middleware/database/instances 中的名称一致
+type Databases interface {
+	// Get 获取名称为 name 的数据库, 实例不存在或未启用时返回 nil
+	Get(name string) Database
+	// Names 返回所有已启用的实例名称
+	Names() []string
+}
+
 // Transaction 定义了满足 SQL 规范的事务接口
 type Transaction interface {
 	Get() *sqlx.Tx
@@ -43,7 +51,34 @@ type Redis interface {
 	Get() redis.UniversalClient
 }
 
+// Redises 为多实例 Redis 的集合, 实例名称与配置中心 /middleware/redis/instances 中的名称一致
+type Redises interface {
+	// Get 获取名称为 name 的 Redis, 实例不存在或未启用时返回 nil
+	Get(name string) Redis
+	// Names 返回所有已启用的实例名称
+	Names() []string
+}
+
+// Mongos 为多实例 MongoDB 的集合, 实例名称与配置中心 /middleware/mongodb/instances 中的名称一致
+type Mongos interface {
+	// Get 获取名称为 name 的 MongoDB, 实例不存在或未启用时返回 nil
+	Get(name string) MongoDB
+	// Names 返回所有已启用的实例名称
+	Names() []string
+}
+
 // MessageQueue 为对消息队列的封装, 抽象具体的操作后允许用户不关注具体使用的消息队列中间件
 type MessageQueue interface {
 	Get() message.QueueFactory
 }
+
+// Disabler 由根据配置未启用的组件实现, 未启用的组件不会为 nil, 但其所有操作都会返回 errorutil.ComponentDisabled 错误
+type Disabler interface {
+	Disabled() bool
+}
+
+// IsDisabled 检查组件是否根据配置未启用
+func IsDisabled(component interface{}) bool {
+	d, ok := component.(Disabler)
+	return ok && d.Disabled()
+}
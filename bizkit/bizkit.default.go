@@ -0,0 +1,122 @@
+package bizkit
+
+import (
+	"fmt"
+
+	configinject "github.com/eden-quan/go-biz-kit/config/inject"
+	"github.com/eden-quan/go-biz-kit/injection"
+	serverinject "github.com/eden-quan/go-biz-kit/server/inject"
+	setupinject "github.com/eden-quan/go-biz-kit/setup/inject"
+)
+
+// Option 为组合默认模块时的配置项
+type Option func(o *options)
+
+type options struct {
+	without  map[string]bool
+	replaces map[string]*injection.Module
+	extras   []*injection.Module
+}
+
+// WithoutModule 不安装名称为 names 的模块, 如 WithoutModule("mongo") 后服务将不会提供 kit.MongoDB,
+// 依赖被移除模块的其他模块会导致安装失败, names 不是默认模块时 Modules 返回错误
+func WithoutModule(names ...string) Option {
+	return func(o *options) {
+		for _, name := range names {
+			o.without[name] = true
+		}
+	}
+}
+
+// Replace 使用 module 替换名称为 name 的模块, 如 Replace("logger", myLoggerModule), module 会以 name 作为名称安装,
+// 因此依赖被替换模块的其他模块无需修改, 但 module 需要提供与被替换模块相同的依赖，否则依赖这些组件的构造函数将无法执行,
+// name 不是默认模块时 Modules 返回错误
+func Replace(name string, module *injection.Module) Option {
+	return func(o *options) {
+		o.replaces[name] = module.As(name)
+	}
+}
+
+// WithModule 在默认模块之外安装额外的模块, 如 configinject.ConfigurationModule()
+func WithModule(modules ...*injection.Module) Option {
+	return func(o *options) {
+		o.extras = append(o.extras, modules...)
+	}
+}
+
+/*
+Modules 返回组合后的默认模块，默认模块包括:
+
+ 1. config: 本地配置及配置中心监听器, 基础配置 *def.Configuration 需要由使用者提供，或通过 WithModule(configinject.ConfigurationModule()) 安装
+ 2. logger / redis / mongo / database / messaging / tracing / client / sqlaction / profile: 由 setup 提供的基础组件
+ 3. server: GRPC / HTTP 服务及 Kratos APP
+
+WithoutModule 或 Replace 指定的名称不是默认模块时返回错误, 避免拼写错误导致配置被忽略
+*/
+func Modules(opts ...Option) ([]*injection.Module, error) {
+	o := &options{
+		without:  make(map[string]bool),
+		replaces: make(map[string]*injection.Module),
+		extras:   make([]*injection.Module, 0),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	defaults := []*injection.Module{configinject.Module()}
+	defaults = append(defaults, setupinject.Modules()...)
+	defaults = append(defaults, serverinject.Module())
+
+	known := make(map[string]bool, len(defaults))
+	for _, m := range defaults {
+		known[m.Name()] = true
+	}
+	for name := range o.without {
+		if !known[name] {
+			return nil, fmt.Errorf("[BizKit] module %s to be removed is not a default module", name)
+		}
+	}
+	for name := range o.replaces {
+		if !known[name] {
+			return nil, fmt.Errorf("[BizKit] module %s to be replaced is not a default module", name)
+		}
+	}
+
+	modules := make([]*injection.Module, 0, len(defaults)+len(o.extras))
+	for _, m := range defaults {
+		if o.without[m.Name()] {
+			continue
+		}
+
+		if r, ok := o.replaces[m.Name()]; ok {
+			m = r
+		}
+		modules = append(modules, m)
+	}
+
+	return append(modules, o.extras...), nil
+}
+
+// Default 将默认模块安装到全局注入器中，用于替代依次调用各个 inject 包的 Inject, 如:
+//
+//	if err := bizkit.Default(bizkit.WithoutModule("mongo")); err != nil {
+//		panic(err)
+//	}
+//	injection.DoIt()
+func Default(opts ...Option) error {
+	return DefaultIns(injection.GlobalInjector(), opts...)
+}
+
+// DefaultIns 使用实例化的方式将默认模块安装到 inj 中
+func DefaultIns(inj *injection.Injector, opts ...Option) error {
+	modules, err := Modules(opts...)
+	if err == nil {
+		err = inj.Install(modules...)
+	}
+	if err != nil {
+		return fmt.Errorf("[BizKit] install default modules failed with error %s", err)
+	}
+
+	return nil
+}
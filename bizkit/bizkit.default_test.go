@@ -0,0 +1,43 @@
+package bizkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/injection"
+	tracinginject "github.com/eden-quan/go-biz-kit/tracing/inject"
+)
+
+// go test -v -count=1 ./bizkit -test.run=TestModules
+func TestModules(t *testing.T) {
+	names := func(modules []*injection.Module) []string {
+		result := make([]string, 0, len(modules))
+		for _, m := range modules {
+			result = append(result, m.Name())
+		}
+		return result
+	}
+
+	modules, err := Modules(
+		WithoutModule("mongo"),
+		Replace("profile", injection.NewModule("my-profile")),
+		WithModule(tracinginject.Module()),
+	)
+	require.NoError(t, err)
+	require.NotContains(t, names(modules), "mongo")
+	require.Contains(t, names(modules), "profile")
+	require.Contains(t, names(modules), "tracing")
+	require.Contains(t, names(modules), "tracinginit")
+
+	// 名称不是默认模块时返回错误, 而不是忽略
+	_, err = Modules(WithoutModule("mongodb"))
+	require.ErrorContains(t, err, "mongodb")
+
+	_, err = Modules(Replace("loger", injection.NewModule("my-logger")))
+	require.ErrorContains(t, err, "loger")
+
+	inj := injection.NewInjector()
+	err = DefaultIns(&inj, WithoutModule("mongodb"))
+	require.Error(t, err)
+}
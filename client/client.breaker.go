@@ -0,0 +1,214 @@
+package clientutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/aegis/circuitbreaker/sre"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+const (
+	BreakerTypeSRE         = "sre"
+	BreakerTypeConsecutive = "consecutive"
+
+	defaultBreakerFailures    = 5
+	defaultBreakerOpenTimeout = time.Second * 10
+)
+
+// breakerKey 为创建熔断器使用的参数, 参数变化时重新创建熔断器, 使用可比较的结构体以避免每次调用都进行反射比较
+type breakerKey struct {
+	typ         string
+	success     float64
+	request     int64
+	bucket      int32
+	window      time.Duration
+	failures    int32
+	openTimeout time.Duration
+}
+
+func newBreakerKey(conf *def.CircuitBreaker) breakerKey {
+	return breakerKey{
+		typ:         conf.GetType(),
+		success:     conf.GetSuccess(),
+		request:     conf.GetRequest(),
+		bucket:      conf.GetBucket(),
+		window:      conf.GetWindow().AsDuration(),
+		failures:    conf.GetFailures(),
+		openTimeout: conf.GetOpenTimeout().AsDuration(),
+	}
+}
+
+// breakerEntry 为单个接口的熔断器及创建熔断器时使用的参数
+type breakerEntry struct {
+	key     breakerKey
+	breaker circuitbreaker.CircuitBreaker
+}
+
+// breakerGroup 按接口维护熔断状态, 配置变更时会使用新的配置重新创建熔断器, 调用路径上只进行无锁读取及参数比较
+type breakerGroup struct {
+	breakers sync.Map // key 为接口的 Operation, value 为 *breakerEntry
+}
+
+func (g *breakerGroup) get(operation string, conf *def.CircuitBreaker) circuitbreaker.CircuitBreaker {
+	key := newBreakerKey(conf)
+
+	value, ok := g.breakers.Load(operation)
+	if ok && value.(*breakerEntry).key == key {
+		return value.(*breakerEntry).breaker
+	}
+
+	entry := &breakerEntry{key: key, breaker: newBreaker(conf)}
+	if !ok {
+		// 首次调用时可能有多个请求同时创建, 使用先写入的熔断器, 避免丢失统计
+		value, _ = g.breakers.LoadOrStore(operation, entry)
+		return value.(*breakerEntry).breaker
+	}
+
+	// 配置变更时可能有多个请求同时创建, 以最后写入的为准
+	g.breakers.Store(operation, entry)
+	return entry.breaker
+}
+
+func newBreaker(conf *def.CircuitBreaker) circuitbreaker.CircuitBreaker {
+	if conf.GetType() == BreakerTypeConsecutive {
+		return newConsecutiveBreaker(conf)
+	}
+
+	opts := make([]sre.Option, 0)
+	if conf.GetSuccess() > 0 {
+		opts = append(opts, sre.WithSuccess(conf.GetSuccess()))
+	}
+	if conf.GetRequest() > 0 {
+		opts = append(opts, sre.WithRequest(conf.GetRequest()))
+	}
+	if conf.GetBucket() > 0 {
+		opts = append(opts, sre.WithBucket(int(conf.GetBucket())))
+	}
+	if conf.GetWindow() != nil && conf.GetWindow().AsDuration() > 0 {
+		opts = append(opts, sre.WithWindow(conf.GetWindow().AsDuration()))
+	}
+
+	return sre.NewBreaker(opts...)
+}
+
+// CircuitBreakerMiddleware 为客户端提供熔断能力, 每个接口使用独立的熔断状态, 熔断配置来自目标服务注册信息中的 circuit_breaker,
+// registry 在每次调用时获取, 配置变更后会重新创建熔断器, 熔断期间的调用直接返回 SERVICE_UNAVAILABLE 错误,
+// 只有服务端异常 (错误码 >= 500) 会被记录为失败
+func CircuitBreakerMiddleware(registry func() *def.Registry) middleware.Middleware {
+	group := &breakerGroup{}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			reg := registry()
+			conf := reg.GetCircuitBreaker()
+			if !conf.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			breaker := group.get(tr.Operation(), conf)
+			if err = breaker.Allow(); err != nil {
+				return nil, errorv1.ErrorServiceUnavailable("circuit breaker is open for %s%s", reg.GetName(), tr.Operation())
+			}
+
+			reply, err = handler(ctx, req)
+			if err != nil && errors.FromError(err).Code >= 500 {
+				breaker.MarkFailed()
+			} else {
+				breaker.MarkSuccess()
+			}
+
+			return reply, err
+		}
+	}
+}
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// consecutiveBreaker 为基于连续失败次数的熔断器, 连续失败达到阈值后熔断, 等待 open_timeout 后进入半开状态,
+// 半开状态下只允许一个请求通过, 该请求成功后恢复, 失败则重新熔断
+type consecutiveBreaker struct {
+	lock        sync.Mutex
+	threshold   int
+	openTimeout time.Duration
+	state       int
+	failures    int
+	openedAt    time.Time
+	probing     bool
+}
+
+func newConsecutiveBreaker(conf *def.CircuitBreaker) *consecutiveBreaker {
+	b := &consecutiveBreaker{
+		threshold:   int(conf.GetFailures()),
+		openTimeout: conf.GetOpenTimeout().AsDuration(),
+	}
+
+	if b.threshold <= 0 {
+		b.threshold = defaultBreakerFailures
+	}
+	if b.openTimeout <= 0 {
+		b.openTimeout = defaultBreakerOpenTimeout
+	}
+
+	return b
+}
+
+func (b *consecutiveBreaker) Allow() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return circuitbreaker.ErrNotAllowed
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return circuitbreaker.ErrNotAllowed
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *consecutiveBreaker) MarkSuccess() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *consecutiveBreaker) MarkFailed() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures += 1
+	b.probing = false
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,86 @@
+package clientutil
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// go test -v -count=1 ./client -test.run=TestConsecutiveBreaker
+func TestConsecutiveBreaker(t *testing.T) {
+	b := newConsecutiveBreaker(&def.CircuitBreaker{
+		Type:        BreakerTypeConsecutive,
+		Failures:    2,
+		OpenTimeout: durationpb.New(time.Millisecond * 20),
+	})
+
+	require.NoError(t, b.Allow())
+	b.MarkFailed()
+	require.NoError(t, b.Allow())
+	b.MarkFailed()
+
+	// 连续失败达到阈值后熔断
+	require.ErrorIs(t, b.Allow(), circuitbreaker.ErrNotAllowed)
+
+	// 等待后进入半开状态，只允许一个请求通过
+	time.Sleep(time.Millisecond * 30)
+	require.NoError(t, b.Allow())
+	require.ErrorIs(t, b.Allow(), circuitbreaker.ErrNotAllowed)
+
+	// 半开状态下失败则重新熔断
+	b.MarkFailed()
+	require.ErrorIs(t, b.Allow(), circuitbreaker.ErrNotAllowed)
+
+	// 半开状态下成功则恢复
+	time.Sleep(time.Millisecond * 30)
+	require.NoError(t, b.Allow())
+	b.MarkSuccess()
+	require.NoError(t, b.Allow())
+	require.NoError(t, b.Allow())
+}
+
+// go test -v -count=1 ./client -test.run=TestBreakerGroup
+func TestBreakerGroup(t *testing.T) {
+	group := &breakerGroup{}
+	conf := &def.CircuitBreaker{Enable: true, Type: BreakerTypeConsecutive, Failures: 2}
+
+	// 并发的首次调用使用同一个熔断器
+	breakers := make([]circuitbreaker.CircuitBreaker, 16)
+	wg := sync.WaitGroup{}
+	for i := range breakers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			breakers[i] = group.get("/api.user.v1.User/Get", conf)
+		}(i)
+	}
+	wg.Wait()
+	for _, b := range breakers {
+		require.Same(t, breakers[0], b)
+	}
+
+	// 配置未变化时复用熔断状态, 不同的接口使用独立的熔断器
+	breaker := breakers[0]
+	breaker.MarkFailed()
+	breaker.MarkFailed()
+	require.ErrorIs(t, group.get("/api.user.v1.User/Get", conf).Allow(), circuitbreaker.ErrNotAllowed)
+	require.NoError(t, group.get("/api.user.v1.User/List", conf).Allow())
+
+	// 阈值变化时重新创建熔断器
+	conf.Failures = 3
+	require.NotSame(t, breaker, group.get("/api.user.v1.User/Get", conf))
+	require.NoError(t, group.get("/api.user.v1.User/Get", conf).Allow())
+
+	conf.OpenTimeout = durationpb.New(time.Minute)
+	_, ok := group.get("/api.user.v1.User/Get", conf).(*consecutiveBreaker)
+	require.True(t, ok)
+	conf.Type = BreakerTypeSRE
+	_, ok = group.get("/api.user.v1.User/Get", conf).(*consecutiveBreaker)
+	require.False(t, ok)
+}
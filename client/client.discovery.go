@@ -0,0 +1,40 @@
+package clientutil
+
+import (
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/discovery"
+)
+
+// newDiscovery 为启用了服务发现的目标服务创建服务发现, 并启用按客户端分发的负载均衡
+func newDiscovery(server *def.Server, reg *def.Registry, etcdClient *etcd.Client, logger log.Logger) (registry.Discovery, error) {
+	if etcdClient == nil {
+		return nil, discovery.ErrClientRequired
+	}
+
+	if reg.GetName() == "" {
+		return nil, fmt.Errorf("service discovery is enabled but registry name is empty, address %s", reg.GetAddress())
+	}
+
+	discovery.SetupBalancer()
+	return discovery.NewEtcdRegistry(etcdClient, server.GetDiscovery(), logger), nil
+}
+
+// discoveryEndpoint 返回通过服务发现调用的地址, 服务名为目标服务的 Registry.name, 即目标服务本地配置中的 app.name
+func discoveryEndpoint(reg *def.Registry) string {
+	return "discovery:///" + reg.GetName()
+}
+
+// tlsAddress 返回校验服务端证书时使用的连接地址, 使用服务发现时实例的地址无法预先确定, 返回空字符串, 此时需要配置 tls.server_name
+func tlsAddress(server *def.Server, reg *def.Registry) string {
+	if server.GetDiscovery().GetEnable() {
+		return ""
+	}
+
+	return reg.GetAddress()
+}
@@ -2,63 +2,235 @@ package clientutil
 
 import (
 	"context"
-	"time"
+	"errors"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-kratos/kratos/v2/log"
 	kgrpc "github.com/go-kratos/kratos/v2/transport/grpc"
+	etcd "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/eden-quan/go-biz-kit/config/def"
+	tlsutil "github.com/eden-quan/go-biz-kit/tls"
 )
 
-// GrpcClientConn 是对 grpc.ClientConn 的简易封装，用于后续实现热更新等能力
-// TODO: 当前使用网关来实现该功能，后续根据需要再支持多节点负载均衡等能力
+// GrpcClientConn 是对 grpc.ClientConn 的简易封装，支持在目标服务的注册信息变化时热更新连接,
+// 目标服务启用了服务发现时通过 ETCD 发现服务实例并在客户端进行负载均衡, 否则直接连接 Registry.address
 type GrpcClientConn struct {
-	*grpc.ClientConn                      // 基于 grpc ClientConn 进行简易封装
-	server           *def.Server          // server 为当前客户端的服务发现信息
-	options          []kgrpc.ClientOption // options 为当前客户端的配置项
-	logger           log.Logger           // 客户端的日志记录器
-	isOk             bool                 // 该连接是否可正常使用
+	conn       atomic.Pointer[trackedConn] // 当前使用的连接, 重连时原子替换
+	server     *def.Server                 // server 为当前客户端的服务发现信息, 由配置中心原地更新
+	etcdClient *etcd.Client                // 服务发现使用的 ETCD 客户端
+	options    []kgrpc.ClientOption        // options 为使用者传入的额外配置项
+	logger     log.Logger                  // 客户端的日志记录器
+
+	lock   sync.Mutex  // 保证同一时间只有一个重连
+	dialed *def.Server // 建立当前连接时使用的配置, 用于判断是否需要重连
+}
+
+// NewGrpcClientConn 根据目标服务的注册信息创建 GRPC 客户端, etcdClient 为服务发现使用的 ETCD 客户端, 未启用服务发现时可以为 nil
+func NewGrpcClientConn(server *def.Server, etcdClient *etcd.Client, logger log.Logger, options ...kgrpc.ClientOption) (*GrpcClientConn, error) {
+	client := &GrpcClientConn{
+		server:     server,
+		etcdClient: etcdClient,
+		options:    options,
+		logger:     logger,
+	}
+
+	conn, err := client.dial()
+	if err != nil {
+		return client, err
+	}
+
+	client.conn.Store(newTrackedConn(conn))
+	client.dialed = dialConfig(server)
+
+	return client, nil
 }
 
-func NewGrpcClientConn(server *def.Server, logger log.Logger, options ...kgrpc.ClientOption) (*GrpcClientConn, error) {
+// dial 根据当前的注册信息建立连接
+func (c *GrpcClientConn) dial() (*grpc.ClientConn, error) {
 	var opts []kgrpc.ClientOption
 
-	opts = append(opts, kgrpc.WithEndpoint(server.GetGrpc().GetAddress()))
+	if c.server.GetDiscovery().GetEnable() {
+		dis, err := newDiscovery(c.server, c.server.GetGrpc(), c.etcdClient, c.logger)
+		if err != nil {
+			return nil, err
+		}
 
-	// 默认超时 5 分钟
-	timeOut := time.Minute * 5
-	if server.GetGrpc() != nil && server.GetGrpc().GetTimeout() != nil {
-		timeOut = server.GetGrpc().GetTimeout().AsDuration()
+		opts = append(opts,
+			kgrpc.WithEndpoint(discoveryEndpoint(c.server.GetGrpc())),
+			kgrpc.WithDiscovery(dis),
+		)
+	} else {
+		opts = append(opts, kgrpc.WithEndpoint(c.server.GetGrpc().GetAddress()))
 	}
-	opts = append(opts, kgrpc.WithTimeout(timeOut))
 
-	opts = append(opts, kgrpc.WithMiddleware(DefaultClientMiddlewares(logger)...))
-	opts = append(opts, options...)
+	// 超时时间由 TimeoutMiddleware 根据目标服务的配置按接口控制, 默认为 5 分钟
+	opts = append(opts, kgrpc.WithTimeout(0))
 
-	client := &GrpcClientConn{
-		ClientConn: nil,
-		server:     server,
-		options:    opts,
-		logger:     logger,
-		isOk:       false,
+	opts = append(opts, kgrpc.WithMiddleware(ClientMiddlewares(c.logger, c.server.GetGrpc, c.server.GetDiscovery)...))
+	opts = append(opts, c.options...)
+
+	// 证书及 CA 的变化在握手时生效, TLS 的开关通过重连生效
+	tlsConf, err := tlsutil.ClientConfig(func() *def.TLS { return c.server.GetGrpc().GetTls() }, func() string { return tlsAddress(c.server, c.server.GetGrpc()) }, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConf != nil {
+		return kgrpc.Dial(context.Background(), append(opts, kgrpc.WithTLSConfig(tlsConf))...)
+	}
+
+	return kgrpc.DialInsecure(context.Background(), opts...)
+}
+
+// Reconnect 在目标服务的地址、超时时间、类型、TLS 或服务发现配置发生变化时建立新的连接并原子替换,
+// 旧连接在处理中的调用完成后关闭, 新连接建立失败时继续使用旧连接
+func (c *GrpcClientConn) Reconnect() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.server.GetGrpc() == nil {
+		return errors.New("grpc registry info is removed, keep using the previous connection")
+	}
+
+	current := dialConfig(c.server)
+	if proto.Equal(current, c.dialed) {
+		return nil
 	}
 
-	err := client.connect()
-	return client, err
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+
+	c.dialed = current
+	if old := c.conn.Swap(newTrackedConn(conn)); old != nil {
+		old.retire()
+	}
+
+	return nil
 }
 
-func (c *GrpcClientConn) connect() error {
-	conn, err := kgrpc.DialInsecure(context.Background(), c.options...)
-	c.ClientConn = conn
-	c.isOk = err == nil
-	return err
+// Conn 返回当前使用的 grpc.ClientConn, 重连后旧的连接会被关闭, 因此不应长期持有
+func (c *GrpcClientConn) Conn() *grpc.ClientConn {
+	if tc := c.conn.Load(); tc != nil {
+		return tc.ClientConn
+	}
+
+	return nil
+}
+
+// Close 在处理中的调用完成后关闭当前连接
+func (c *GrpcClientConn) Close() error {
+	if tc := c.conn.Swap(nil); tc != nil {
+		tc.retire()
+	}
+
+	return nil
 }
 
 func (c *GrpcClientConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
-	return c.ClientConn.Invoke(ctx, method, args, reply, opts...)
+	tc, err := c.acquire()
+	if err != nil {
+		return err
+	}
+	defer tc.release()
+
+	return tc.Invoke(ctx, method, args, reply, opts...)
 }
 
 func (c *GrpcClientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-	return c.ClientConn.NewStream(ctx, desc, method, opts...)
+	tc, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := tc.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		tc.release()
+		return nil, err
+	}
+
+	// 流结束时 grpc 会取消流的 context, 此时才释放连接
+	go func() {
+		<-stream.Context().Done()
+		tc.release()
+	}()
+
+	return stream, nil
+}
+
+// acquire 获取当前连接并增加引用计数, 使用完毕后需要调用 release
+func (c *GrpcClientConn) acquire() (*trackedConn, error) {
+	for {
+		tc := c.conn.Load()
+		if tc == nil {
+			return nil, grpc.ErrClientConnClosing
+		}
+
+		if tc.acquire() {
+			return tc, nil
+		}
+		// 获取期间连接被替换, 重新获取新的连接
+	}
+}
+
+// dialConfig 返回注册信息中影响连接的部分, 注册信息会被配置中心原地更新, 因此需要复制
+func dialConfig(server *def.Server) *def.Server {
+	return proto.Clone(&def.Server{
+		Grpc: &def.Registry{
+			Name:    server.GetGrpc().GetName(),
+			Address: server.GetGrpc().GetAddress(),
+			Timeout: server.GetGrpc().GetTimeout(),
+			Type:    server.GetGrpc().GetType(),
+			Tls:     server.GetGrpc().GetTls(),
+		},
+		Discovery: server.GetDiscovery(),
+	}).(*def.Server)
+}
+
+// trackedConn 为带引用计数的连接, 被替换后在引用计数归零时关闭
+type trackedConn struct {
+	*grpc.ClientConn
+	refs    atomic.Int64
+	retired atomic.Bool
+	once    sync.Once
+}
+
+func newTrackedConn(conn *grpc.ClientConn) *trackedConn {
+	return &trackedConn{ClientConn: conn}
+}
+
+// acquire 增加引用计数, 连接已被替换时返回 false
+func (t *trackedConn) acquire() bool {
+	t.refs.Add(1)
+	if t.retired.Load() {
+		t.release()
+		return false
+	}
+
+	return true
+}
+
+func (t *trackedConn) release() {
+	if t.refs.Add(-1) == 0 && t.retired.Load() {
+		t.close()
+	}
+}
+
+// retire 标记连接已被替换, 没有处理中的调用时立即关闭
+func (t *trackedConn) retire() {
+	t.retired.Store(true)
+	if t.refs.Load() == 0 {
+		t.close()
+	}
+}
+
+func (t *trackedConn) close() {
+	t.once.Do(func() {
+		_ = t.ClientConn.Close()
+	})
 }
@@ -0,0 +1,63 @@
+package clientutil
+
+import (
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// go test -v -count=1 ./client -test.run=TestTrackedConn
+func TestTrackedConn(t *testing.T) {
+	conn, err := grpc.Dial("127.0.0.1:0", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	tc := newTrackedConn(conn)
+	require.True(t, tc.acquire())
+
+	// 被替换后不再允许获取, 处理中的调用完成前不会关闭
+	tc.retire()
+	require.False(t, tc.acquire())
+	require.NotEqual(t, connectivity.Shutdown, conn.GetState())
+
+	// 最后一个调用完成后关闭
+	tc.release()
+	require.Equal(t, connectivity.Shutdown, conn.GetState())
+}
+
+// go test -v -count=1 ./client -test.run=TestReconnect
+func TestReconnect(t *testing.T) {
+	server := &def.Server{Grpc: &def.Registry{Address: "127.0.0.1:8001"}}
+	client, err := NewGrpcClientConn(server, nil, log.DefaultLogger)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	// 注册信息未变化时不重连
+	first := client.Conn()
+	require.NoError(t, client.Reconnect())
+	require.Same(t, first, client.Conn())
+
+	// 新连接建立失败时继续使用旧连接
+	server.Grpc.Tls = &def.TLS{Enable: true, CaPem: "invalid"}
+	require.Error(t, client.Reconnect())
+	require.Same(t, first, client.Conn())
+
+	// 地址变化后切换到新连接, 旧连接在处理中的调用完成后关闭
+	inflight, err := client.acquire()
+	require.NoError(t, err)
+
+	server.Grpc.Tls = nil
+	server.Grpc.Address = "127.0.0.1:8002"
+	require.NoError(t, client.Reconnect())
+	require.NotSame(t, first, client.Conn())
+	require.Equal(t, "127.0.0.1:8002", client.Conn().Target())
+	require.NotEqual(t, connectivity.Shutdown, first.GetState())
+
+	inflight.release()
+	require.Equal(t, connectivity.Shutdown, first.GetState())
+}
@@ -2,32 +2,242 @@ package clientutil
 
 import (
 	"context"
-	"time"
+	"errors"
+	nethttp "net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	etcd "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/eden-quan/go-biz-kit/config/def"
+	tlsutil "github.com/eden-quan/go-biz-kit/tls"
 )
 
-func NewHttpClientConn(server *def.Server, logger log.Logger) (*http.Client, error) {
-	opts := make([]http.ClientOption, 0)
+// NewHttpClientConn 根据目标服务的注册信息创建 HTTP 客户端, 目标服务启用了服务发现时通过 ETCD 发现服务实例并在客户端进行负载均衡,
+// etcdClient 为服务发现使用的 ETCD 客户端, 未启用服务发现时可以为 nil,
+// 超时时间、证书及 CA 在每次调用时读取最新的配置, 其他注册信息的变化需要通过 RegisterHTTPClient 创建的客户端才会生效
+func NewHttpClientConn(server *def.Server, etcdClient *etcd.Client, logger log.Logger) (*http.Client, error) {
+	client, _, err := newHttpClientConn(server, etcdClient, logger)
+	return client, err
+}
+
+// newHttpClientConn 创建 HTTP 客户端, 同时返回客户端使用的 httpTransport, 用于在注册信息变化时重建传输层
+func newHttpClientConn(server *def.Server, etcdClient *etcd.Client, logger log.Logger) (*http.Client, *httpTransport, error) {
+	transport := &httpTransport{server: server, etcdClient: etcdClient, logger: logger}
+	route, err := transport.build()
+	if err != nil {
+		return nil, nil, err
+	}
+	transport.built = routeConfig(server)
+	transport.route.Store(route)
+
+	// Kratos 的 HTTP 客户端创建后无法修改地址及服务发现, 因此客户端只使用占位的地址, 由 httpTransport 发送到当前的目标
+	endpoint := server.GetHttp().GetAddress()
+	if server.GetDiscovery().GetEnable() {
+		endpoint = server.GetHttp().GetName()
+	}
+
+	client, err := http.NewClient(context.Background(),
+		http.WithEndpoint(endpoint),
+		http.WithTransport(transport),
+		// 超时时间由 TimeoutMiddleware 根据目标服务的配置按接口控制, 默认为 5 分钟
+		http.WithTimeout(0),
+		http.WithMiddleware(ClientMiddlewares(logger, server.GetHttp, server.GetDiscovery)...),
+	)
+	if err != nil {
+		route.close()
+		return nil, nil, err
+	}
+
+	return client, transport, nil
+}
+
+// httpTransport 为 HTTP 客户端使用的传输层, 注册信息中的地址、服务发现、类型或 TLS 配置变化时重建内部的 httpRoute 并原子替换,
+// 替换后关闭旧 httpRoute 的空闲连接, 处理中的请求在原连接上完成, 之后的空闲连接由 IdleConnTimeout 关闭
+type httpTransport struct {
+	route      atomic.Pointer[httpRoute] // 当前使用的传输层
+	server     *def.Server               // server 为当前客户端的服务发现信息, 由配置中心原地更新
+	etcdClient *etcd.Client              // 服务发现使用的 ETCD 客户端
+	logger     log.Logger
+
+	lock  sync.Mutex  // 保证同一时间只有一个重建
+	built *def.Server // 创建当前传输层时使用的配置, 用于判断是否需要重建
+}
+
+// Reload 在注册信息变化时重建传输层, 重建失败时继续使用之前的传输层
+func (t *httpTransport) Reload() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.server.GetHttp() == nil {
+		return errors.New("http registry info is removed, keep using the previous transport")
+	}
+
+	current := routeConfig(t.server)
+	if proto.Equal(current, t.built) {
+		return nil
+	}
 
-	opts = append(opts, http.WithEndpoint(server.GetHttp().GetAddress()))
+	route, err := t.build()
+	if err != nil {
+		return err
+	}
 
-	// 默认超时 5 分钟
-	timeOut := time.Minute * 5
-	if server.GetHttp() != nil && server.GetHttp().GetTimeout() != nil {
-		timeOut = server.GetHttp().GetTimeout().AsDuration()
+	t.built = current
+	if old := t.route.Swap(route); old != nil {
+		old.close()
 	}
-	opts = append(opts, http.WithTimeout(timeOut))
 
-	opts = append(opts, http.WithMiddleware(DefaultClientMiddlewares(logger)...))
+	return nil
+}
+
+func (t *httpTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	return t.route.Load().RoundTrip(req)
+}
+
+// build 根据当前的注册信息创建传输层
+func (t *httpTransport) build() (*httpRoute, error) {
+	reg := t.server.GetHttp()
+
+	tlsConf, err := tlsutil.ClientConfig(func() *def.TLS { return t.server.GetHttp().GetTls() }, func() string { return tlsAddress(t.server, reg) }, t.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	base := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+	if tlsConf != nil {
+		base.TLSClientConfig = tlsConf
+	}
+
+	if !t.server.GetDiscovery().GetEnable() {
+		if reg.GetAddress() == "" {
+			return nil, errors.New("http registry address is empty")
+		}
+		return &httpRoute{base: base, direct: newEndpointTransport(base, tlsConf != nil, reg.GetAddress())}, nil
+	}
+
+	dis, err := newDiscovery(t.server, reg, t.etcdClient, t.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []http.ClientOption{
+		http.WithEndpoint(discoveryEndpoint(reg)),
+		http.WithDiscovery(dis),
+		http.WithTransport(base),
+		http.WithTimeout(0),
+		// 错误由外层的客户端解析, 这里只负责选择实例
+		http.WithErrorDecoder(func(context.Context, *nethttp.Response) error { return nil }),
+	}
+	if tlsConf != nil {
+		opts = append(opts, http.WithTLSConfig(tlsConf))
+	}
 
 	client, err := http.NewClient(context.Background(), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return client, err
+	return &httpRoute{base: base, discovery: client}, nil
+}
+
+// routeConfig 返回创建传输层时使用的注册信息, 注册信息会被配置中心原地更新, 因此需要复制,
+// 超时时间在每次调用时读取, 不需要重建
+func routeConfig(server *def.Server) *def.Server {
+	return proto.Clone(&def.Server{
+		Http: &def.Registry{
+			Name:    server.GetHttp().GetName(),
+			Address: server.GetHttp().GetAddress(),
+			Type:    server.GetHttp().GetType(),
+			Tls:     server.GetHttp().GetTls(),
+		},
+		Discovery: server.GetDiscovery(),
+	}).(*def.Server)
+}
+
+// httpRoute 为根据某一份注册信息创建的传输层, 未启用服务发现时直接发送到 Registry.address, 否则由服务发现选择实例
+type httpRoute struct {
+	base      *nethttp.Transport
+	direct    *endpointTransport
+	discovery *http.Client
+}
+
+func (r *httpRoute) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	if r.discovery == nil {
+		return r.direct.RoundTrip(req)
+	}
+
+	// RoundTripper 不能修改请求, 服务发现选择实例时会修改请求的地址; 请求的 context 中包含 Balancer 中间件写入的策略
+	return r.discovery.Do(req.Clone(req.Context()))
+}
+
+// close 停止服务发现并关闭空闲连接
+func (r *httpRoute) close() {
+	if r.discovery != nil {
+		_ = r.discovery.Close()
+	}
+	r.base.CloseIdleConnections()
+}
+
+// endpointTransport 将请求发送到目标服务当前的地址, 地址变化后关闭旧地址的空闲连接, 处理中的请求在原连接上完成
+type endpointTransport struct {
+	base    *nethttp.Transport
+	secure  bool // 是否启用了 TLS, 地址中未指定协议时使用 https, 不能根据 base.TLSClientConfig 判断, 复制的 DefaultTransport 总是包含该配置
+	address atomic.Pointer[url.URL]
+}
+
+func newEndpointTransport(base *nethttp.Transport, secure bool, address string) *endpointTransport {
+	t := &endpointTransport{
+		base:   base,
+		secure: secure,
+	}
+	_ = t.SetAddress(address)
+
+	return t
+}
+
+// SetAddress 切换目标服务的地址, address 格式与 Registry.address 相同, 如 127.0.0.1:8000 或 http://user-service:8000
+func (t *endpointTransport) SetAddress(address string) error {
+	if address == "" {
+		return errors.New("http registry address is empty")
+	}
+
+	if !strings.Contains(address, "://") {
+		if t.secure {
+			address = "https://" + address
+		} else {
+			address = "http://" + address
+		}
+	}
+
+	target, err := url.Parse(address)
+	if err != nil {
+		return err
+	}
+
+	old := t.address.Swap(target)
+	if old != nil && old.Host != target.Host {
+		t.base.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+func (t *endpointTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	target := t.address.Load()
+	if target == nil || (req.URL.Scheme == target.Scheme && req.URL.Host == target.Host) {
+		return t.base.RoundTrip(req)
+	}
+
+	r := req.Clone(req.Context())
+	r.URL.Scheme = target.Scheme
+	r.URL.Host = target.Host
+	r.Host = target.Host
+
+	return t.base.RoundTrip(r)
 }
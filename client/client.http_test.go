@@ -0,0 +1,116 @@
+package clientutil
+
+import (
+	"encoding/pem"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// go test -v -count=1 ./client -test.run=TestEndpointTransport
+func TestEndpointTransport(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+			_, _ = w.Write([]byte(name))
+		}))
+	}
+	first, second := newServer("first"), newServer("second")
+	defer first.Close()
+	defer second.Close()
+
+	transport := newEndpointTransport(nethttp.DefaultTransport.(*nethttp.Transport).Clone(), false, first.Listener.Addr().String())
+	client := &nethttp.Client{Transport: transport}
+
+	get := func() string {
+		// 请求的地址为客户端创建时的地址, 由 endpointTransport 转发到当前的地址
+		resp, err := client.Get(first.URL + "/ping")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	require.Equal(t, "first", get())
+
+	require.NoError(t, transport.SetAddress(second.URL))
+	require.Equal(t, "second", get())
+
+	// 地址无效时继续使用之前的地址
+	require.Error(t, transport.SetAddress(""))
+	require.Equal(t, "second", get())
+}
+
+// go test -v -count=1 ./client -test.run=TestHttpTransportReload
+func TestHttpTransportReload(t *testing.T) {
+	handler := func(name string) nethttp.Handler {
+		return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+			_, _ = w.Write([]byte(name))
+		})
+	}
+	first, second, secure := httptest.NewServer(handler("first")), httptest.NewServer(handler("second")), httptest.NewTLSServer(handler("secure"))
+	defer first.Close()
+	defer second.Close()
+	defer secure.Close()
+
+	server := &def.Server{Http: &def.Registry{Name: "user-service", Address: first.Listener.Addr().String(), Type: "http"}}
+	client, transport, err := newHttpClientConn(server, nil, log.DefaultLogger)
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	get := func() string {
+		// 请求的地址为 Kratos 客户端中占位的地址, 由 httpTransport 发送到当前的目标
+		resp, err := (&nethttp.Client{Transport: transport}).Get("http://user-service/ping")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	require.Equal(t, "first", get())
+
+	// 注册信息未变化时不重建
+	route := transport.route.Load()
+	require.NoError(t, transport.Reload())
+	require.Same(t, route, transport.route.Load())
+
+	// 地址变化时重建并切换
+	server.Http.Address = second.Listener.Addr().String()
+	require.NoError(t, transport.Reload())
+	require.NotSame(t, route, transport.route.Load())
+	require.Equal(t, "second", get())
+
+	// 重建失败时继续使用之前的传输层
+	server.Http.Tls = &def.TLS{Enable: true, CaPem: "invalid"}
+	require.Error(t, transport.Reload())
+	require.Equal(t, "second", get())
+
+	server.Http.Tls = nil
+	server.Discovery = &def.Discovery{Enable: true}
+	require.Error(t, transport.Reload())
+	require.Equal(t, "second", get())
+
+	// 启用 TLS 后使用 https 连接新的地址
+	server.Discovery = nil
+	server.Http.Address = secure.Listener.Addr().String()
+	server.Http.Tls = &def.TLS{
+		Enable: true,
+		CaPem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: secure.Certificate().Raw})),
+	}
+	require.NoError(t, transport.Reload())
+	require.Equal(t, "secure", get())
+
+	// 注册信息被删除时继续使用之前的传输层
+	server.Http = nil
+	require.Error(t, transport.Reload())
+	require.Equal(t, "secure", get())
+}
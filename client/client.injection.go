@@ -24,7 +24,8 @@ func RegisterHTTPClient(nameAndType []string, repo config.ConfigureWatcherRepo,
 	if serviceName[0] == '/' {
 		serviceName = serviceName[1:]
 	}
-	err := repo.LoadWithPath(server, fmt.Sprintf("/registry/%s/config", serviceName))
+	path := fmt.Sprintf("/registry/%s/config", serviceName)
+	err := repo.LoadWithPath(server, path)
 	err = repo.Start()
 
 	if err != nil {
@@ -35,7 +36,19 @@ func RegisterHTTPClient(nameAndType []string, repo config.ConfigureWatcherRepo,
 		if server.GetHttp() == nil {
 			return nil, fmt.Errorf("can not find http registry info for %s, please check the config center (etcd)", serviceName)
 		}
-		return NewHttpClientConn(server, logger)
+
+		client, transport, err := newHttpClientConn(server, repo.EtcdClient(), logger)
+		if err != nil {
+			return client, err
+		}
+
+		// 注册信息变化时重建传输层, 客户端本身不变
+		repo.OnChange(path, func() {
+			if err := transport.Reload(); err != nil {
+				log.NewHelper(logger).Errorf("reload http client of %s failed with error %s", serviceName, err)
+			}
+		})
+		return client, nil
 	}
 
 	return nil, fmt.Errorf("registry got wrong type %s for service %s", serviceType, serviceName)
@@ -53,7 +66,8 @@ func RegisterGRPCClient(nameAndType []string, repo config.ConfigureWatcherRepo,
 	if serviceName[0] == '/' {
 		serviceName = serviceName[1:]
 	}
-	err := repo.LoadWithPath(server, fmt.Sprintf("/registry/%s/config", serviceName))
+	path := fmt.Sprintf("/registry/%s/config", serviceName)
+	err := repo.LoadWithPath(server, path)
 	err = repo.Start()
 
 	//err := repo.LoadAndStart(server)
@@ -65,7 +79,19 @@ func RegisterGRPCClient(nameAndType []string, repo config.ConfigureWatcherRepo,
 		if server.GetGrpc() == nil {
 			return nil, fmt.Errorf("can not find grpc registry info for %s, please check the config center (etcd)", serviceName)
 		}
-		return NewGrpcClientConn(server, logger)
+
+		conn, err := NewGrpcClientConn(server, repo.EtcdClient(), logger)
+		if err != nil {
+			return nil, err
+		}
+
+		// 注册信息变化时重新建立连接
+		repo.OnChange(path, func() {
+			if err := conn.Reconnect(); err != nil {
+				log.NewHelper(logger).Errorf("reconnect grpc client of %s failed with error %s", serviceName, err)
+			}
+		})
+		return conn, nil
 	}
 
 	return nil, fmt.Errorf("registry got wrong type %s for service %s", serviceType, serviceName)
@@ -11,6 +11,11 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/transport"
 
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/discovery"
+	"github.com/eden-quan/go-biz-kit/metrics"
+	"github.com/eden-quan/go-biz-kit/payload"
+	"github.com/eden-quan/go-biz-kit/signature"
 	"github.com/eden-quan/go-biz-kit/tracing"
 )
 
@@ -42,3 +47,23 @@ func DefaultClientMiddlewares(logger log.Logger) []middleware.Middleware {
 		AuthorizationMiddleware(),
 	}
 }
+
+// ClientMiddlewares 在 DefaultClientMiddlewares 的基础上增加监控指标、内容日志、按接口配置的超时控制、重试、熔断及负载均衡策略, registry 返回目标服务的注册信息,
+// discovery 返回目标服务的服务发现配置, 通过 NewGrpcClientConn 及 NewHttpClientConn 创建的客户端默认使用该调用链,
+// 超时时间包含所有的重试, 每次重试都会经过熔断器并重新选择服务实例, 并使用 signature.Setup 配置的私钥重新签名
+func ClientMiddlewares(logger log.Logger, registry func() *def.Registry, discoveryConf func() *def.Discovery) []middleware.Middleware {
+	return []middleware.Middleware{
+		recovery.Recovery(),
+		metadata.Client(),
+		tracing.Client(),
+		metrics.Client(),
+		TimeoutMiddleware(registry),
+		RetryMiddleware(registry),
+		CircuitBreakerMiddleware(registry),
+		discovery.Balancer(discoveryConf),
+		middlewarepkg.ClientLogging(logger),
+		payload.Client(logger),
+		AuthorizationMiddleware(),
+		signature.Client(),
+	}
+}
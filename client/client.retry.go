@@ -0,0 +1,151 @@
+package clientutil
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryBackoff    = time.Millisecond * 100
+	defaultRetryMaxBackoff = time.Second * 2
+	defaultRetryMultiplier = 2.0
+)
+
+// idempotentMethods 缓存通过 proto 的 idempotency_level 声明的接口幂等性
+var idempotentMethods sync.Map
+
+// RetryMiddleware 为幂等的接口提供重试能力, 重试配置来自目标服务注册信息中的 retry, registry 在每次调用时获取,
+// 只有网络异常 (NETWORK_ERROR / NETWORK_TIMEOUT 及连接失败) 会触发重试, 每次重试前按照指数退避并加入随机抖动进行等待,
+// 剩余的超时时间不足以等待时不再重试, 每次重试都会作为事件记录在 tracing.Client 创建的链路中
+func RetryMiddleware(registry func() *def.Registry) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			conf := registry().GetRetry()
+			if !conf.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok || !isIdempotent(conf, tr.Operation()) {
+				return handler(ctx, req)
+			}
+
+			attempts := int(conf.GetMaxAttempts())
+			if attempts <= 0 {
+				attempts = defaultRetryAttempts
+			}
+
+			span := trace.SpanFromContext(ctx)
+			for attempt := 1; ; attempt++ {
+				reply, err = handler(ctx, req)
+				if err == nil || attempt >= attempts || !isRetryable(err) {
+					return reply, err
+				}
+
+				backoff := retryBackoff(conf, attempt)
+				if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+					return reply, err
+				}
+
+				span.AddEvent("retry", trace.WithAttributes(
+					attribute.Int("retry.attempt", attempt+1),
+					attribute.String("retry.backoff", backoff.String()),
+					attribute.String("retry.error", err.Error()),
+				))
+
+				select {
+				case <-ctx.Done():
+					return reply, err
+				case <-time.After(backoff):
+				}
+
+				// HTTP 请求的 Body 在发送后已被读取, 重试前需要重置
+				if ht, ok := tr.(khttp.Transporter); ok && ht.Request().GetBody != nil {
+					if body, bodyErr := ht.Request().GetBody(); bodyErr == nil {
+						ht.Request().Body = body
+					}
+				}
+			}
+		}
+	}
+}
+
+// retryBackoff 返回第 attempt 次调用失败后的等待时间, 等待时间在指数退避的基础上加入 [50%, 100%] 的随机抖动
+func retryBackoff(conf *def.Retry, attempt int) time.Duration {
+	initial, maxBackoff, multiplier := defaultRetryBackoff, defaultRetryMaxBackoff, defaultRetryMultiplier
+	if conf.GetInitialBackoff().AsDuration() > 0 {
+		initial = conf.GetInitialBackoff().AsDuration()
+	}
+	if conf.GetMaxBackoff().AsDuration() > 0 {
+		maxBackoff = conf.GetMaxBackoff().AsDuration()
+	}
+	if conf.GetMultiplier() > 1 {
+		multiplier = conf.GetMultiplier()
+	}
+
+	backoff := math.Min(float64(maxBackoff), float64(initial)*math.Pow(multiplier, float64(attempt-1)))
+	return time.Duration(backoff/2 + rand.Float64()*backoff/2)
+}
+
+// isRetryable 检查错误是否为可重试的网络异常
+func isRetryable(err error) bool {
+	if errorv1.IsNetworkError(err) || errorv1.IsNetworkTimeout(err) {
+		return true
+	}
+
+	// 连接失败时 GRPC 返回 Unavailable, HTTP 返回 net.Error
+	var netErr net.Error
+	if stderrors.As(err, &netErr) {
+		return true
+	}
+
+	e := errors.FromError(err)
+	return e.Code == 503 && e.Reason == ""
+}
+
+// isIdempotent 检查接口是否幂等, 优先使用配置中的 idempotent_operations, 其次使用 proto 中声明的 idempotency_level
+func isIdempotent(conf *def.Retry, operation string) bool {
+	for _, pattern := range conf.GetIdempotentOperations() {
+		if pattern == operation || (strings.HasSuffix(pattern, "*") && strings.HasPrefix(operation, strings.TrimSuffix(pattern, "*"))) {
+			return true
+		}
+	}
+
+	if v, ok := idempotentMethods.Load(operation); ok {
+		return v.(bool)
+	}
+
+	idempotent := false
+	// Operation 的格式为 /package.Service/Method, 对应的描述符名称为 package.Service.Method
+	name := strings.Replace(strings.TrimPrefix(operation, "/"), "/", ".", 1)
+	if desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name)); err == nil {
+		if md, ok := desc.(protoreflect.MethodDescriptor); ok {
+			if opts, ok := md.Options().(*descriptorpb.MethodOptions); ok {
+				idempotent = opts.GetIdempotencyLevel() != descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN
+			}
+		}
+	}
+
+	idempotentMethods.Store(operation, idempotent)
+	return idempotent
+}
@@ -0,0 +1,51 @@
+package clientutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+type fakeTransport struct {
+	operation string
+}
+
+func (t *fakeTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *fakeTransport) Endpoint() string                { return "" }
+func (t *fakeTransport) Operation() string               { return t.operation }
+func (t *fakeTransport) RequestHeader() transport.Header { return nil }
+func (t *fakeTransport) ReplyHeader() transport.Header   { return nil }
+
+// go test -v -count=1 ./client -test.run=TestRetryMiddleware
+func TestRetryMiddleware(t *testing.T) {
+	registry := &def.Registry{Retry: &def.Retry{
+		Enable:               true,
+		MaxAttempts:          3,
+		InitialBackoff:       durationpb.New(time.Millisecond),
+		IdempotentOperations: []string{"/api.user.v1.User/Get*"},
+	}}
+	mid := RetryMiddleware(func() *def.Registry { return registry })
+
+	call := func(operation string, err error) int {
+		calls := 0
+		ctx := transport.NewClientContext(context.Background(), &fakeTransport{operation: operation})
+		_, _ = mid(func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls += 1
+			return nil, err
+		})(ctx, nil)
+		return calls
+	}
+
+	// 幂等接口的网络异常会重试到最大次数
+	require.Equal(t, 3, call("/api.user.v1.User/GetUser", errorv1.ErrorNetworkError("unreachable")))
+	// 非幂等接口及非网络异常不会重试
+	require.Equal(t, 1, call("/api.user.v1.User/CreateUser", errorv1.ErrorNetworkError("unreachable")))
+	require.Equal(t, 1, call("/api.user.v1.User/GetUser", errorv1.ErrorInvalidParameter("invalid")))
+}
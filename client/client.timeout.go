@@ -0,0 +1,28 @@
+package clientutil
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// TimeoutMiddleware 根据目标服务的 registry 配置为每个调用设置超时时间, 具体见 def.Registry.OperationTimeout,
+// registry 在每次调用时获取, 因此目标服务在配置中心中修改的 operation_timeouts 无需重新创建客户端即可生效
+func TimeoutMiddleware(registry func() *def.Registry) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, registry().OperationTimeout(tr.Operation()))
+			defer cancel()
+
+			return handler(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v5.26.1
+// source: common/def/sensitive.v1.proto
+
+package def
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_common_def_sensitive_v1_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50100,
+		Name:          "kit.default.configv1.sensitive",
+		Tag:           "varint,50100,opt,name=sensitive",
+		Filename:      "common/def/sensitive.v1.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50101,
+		Name:          "kit.default.configv1.redact",
+		Tag:           "varint,50101,opt,name=redact",
+		Filename:      "common/def/sensitive.v1.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// sensitive 标记敏感字段, 如 string password = 1 [(kit.default.configv1.sensitive) = true];
+	// 字段值由调用方使用 Secret.transfer_encrypt 的公钥加密 (RSA-OAEP SHA256, base64 编码), 服务端在业务处理前解密, 日志中记录脱敏后的值
+	//
+	// optional bool sensitive = 50100;
+	E_Sensitive = &file_common_def_sensitive_v1_proto_extTypes[0]
+	// redact 标记需要在日志中脱敏的字段, 如 string phone = 2 [(kit.default.configv1.redact) = true];
+	//
+	// optional bool redact = 50101;
+	E_Redact = &file_common_def_sensitive_v1_proto_extTypes[1]
+)
+
+var File_common_def_sensitive_v1_proto protoreflect.FileDescriptor
+
+var file_common_def_sensitive_v1_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x64, 0x65, 0x66, 0x2f, 0x73, 0x65, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x14, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x76, 0x31, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3a, 0x3d, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x76, 0x65, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xb4, 0x87, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x3a, 0x37, 0x0a, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74,
+	0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0xb5, 0x87, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x42,
+	0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x64,
+	0x65, 0x6e, 0x2d, 0x71, 0x75, 0x61, 0x6e, 0x2f, 0x67, 0x6f, 0x2d, 0x62, 0x69, 0x7a, 0x2d, 0x6b,
+	0x69, 0x74, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x64, 0x65, 0x66, 0x3b, 0x64, 0x65,
+	0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_common_def_sensitive_v1_proto_goTypes = []interface{}{
+	(*descriptorpb.FieldOptions)(nil), // 0: google.protobuf.FieldOptions
+}
+var file_common_def_sensitive_v1_proto_depIdxs = []int32{
+	0, // 0: kit.default.configv1.sensitive:extendee -> google.protobuf.FieldOptions
+	0, // 1: kit.default.configv1.redact:extendee -> google.protobuf.FieldOptions
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	0, // [0:2] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_common_def_sensitive_v1_proto_init() }
+func file_common_def_sensitive_v1_proto_init() {
+	if File_common_def_sensitive_v1_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_common_def_sensitive_v1_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_def_sensitive_v1_proto_goTypes,
+		DependencyIndexes: file_common_def_sensitive_v1_proto_depIdxs,
+		ExtensionInfos:    file_common_def_sensitive_v1_proto_extTypes,
+	}.Build()
+	File_common_def_sensitive_v1_proto = out.File
+	file_common_def_sensitive_v1_proto_rawDesc = nil
+	file_common_def_sensitive_v1_proto_goTypes = nil
+	file_common_def_sensitive_v1_proto_depIdxs = nil
+}
@@ -1,5 +1,9 @@
 package config
 
+import (
+	etcd "go.etcd.io/etcd/client/v3"
+)
+
 type ConfigureWatcherRepo interface {
 	// Load 解析配置对象 object 中的 `conf_path` 标签及 json 标签，获取配置对象配置中心路径的映射
 	Load(object interface{}) error
@@ -14,4 +18,11 @@ type ConfigureWatcherRepo interface {
 
 	// LoadWithPath 为 object 建立 path 的监听，并在 path 发生变化时为其提供热更新能力, 该接口一般用于运行时需要动态监听配置的情形
 	LoadWithPath(object interface{}, path string) error
+
+	// OnChange 注册 path 对应的配置更新后的回调, 回调在配置中心的监听协程中执行, 此时绑定的对象已经完成更新,
+	// 一般与 LoadWithPath 配合使用, 用于在配置变化时重建连接等无法直接读取最新配置的场景
+	OnChange(path string, callback func())
+
+	// EtcdClient 返回配置中心的 ETCD 客户端, 使用本地配置文件时返回 nil, 服务发现等需要访问 ETCD 的组件可以复用该客户端
+	EtcdClient() *etcd.Client
 }
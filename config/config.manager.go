@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	etcd "go.etcd.io/etcd/client/v3"
@@ -18,16 +19,38 @@ type Manager struct {
 	TraceInfo   traceInfo
 	WatchPath   []string
 	PathHistory map[string]*pathPriorityHistory
+
+	listenerLock sync.Mutex
+	listeners    map[string][]func() // listeners 为各个 path 配置更新后的回调
 }
 
-// NewConfigWatcher 创建配置中心监听器, 他依赖于本地配置提供的配置中心地址
-func NewConfigWatcher(configure *LocalConfigure) (ConfigureWatcherRepo, error) {
-	// TODO: 将 ETCD  Client 抽取出来作为独立的依赖项
+// NewEtcdClient 根据本地配置提供的配置中心地址创建 ETCD 客户端, 配置中心及服务注册发现共用该客户端,
+// 配置了本地文件时不会连接 ETCD, 此时返回 nil
+func NewEtcdClient(configure *LocalConfigure) (*etcd.Client, error) {
+	if configure.ConfigCenter.LocalFile != "" {
+		return nil, nil
+	}
+
 	timeOut, err := time.ParseDuration(configure.ConfigCenter.Timeout)
 	if err != nil {
 		return nil, err
 	}
 
+	client, err := etcd.New(etcd.Config{
+		Endpoints:   configure.ConfigCenter.Endpoints,
+		Username:    configure.ConfigCenter.Username,
+		Password:    configure.ConfigCenter.Password,
+		DialTimeout: timeOut,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect to etcd failed with error %w", err)
+	}
+
+	return client, nil
+}
+
+// NewConfigWatcher 创建配置中心监听器, 他依赖于 NewEtcdClient 提供的配置中心客户端
+func NewConfigWatcher(configure *LocalConfigure, client *etcd.Client) (ConfigureWatcherRepo, error) {
 	e := Manager{
 		LocalFile:   configure.ConfigCenter.LocalFile,
 		Instances:   make([]*managerInstance, 0),
@@ -37,16 +60,8 @@ func NewConfigWatcher(configure *LocalConfigure) (ConfigureWatcherRepo, error) {
 
 	// 没有配置本地文件时才会监听 ETCD
 	if configure.ConfigCenter.LocalFile == "" {
-		client, err := etcd.New(etcd.Config{
-			Endpoints:   configure.ConfigCenter.Endpoints,
-			Username:    configure.ConfigCenter.Username,
-			Password:    configure.ConfigCenter.Password,
-			DialTimeout: timeOut,
-		})
-
-		if err != nil {
-			panic(fmt.Sprint("connect to etcd failed with error", err))
-			return nil, err
+		if client == nil {
+			return nil, errors.New("config center (etcd) client is required when local_file is empty")
 		}
 
 		e.Client = client
@@ -56,6 +71,11 @@ func NewConfigWatcher(configure *LocalConfigure) (ConfigureWatcherRepo, error) {
 	return &e, nil
 }
 
+// EtcdClient 返回配置中心的 ETCD 客户端, 使用本地配置文件时返回 nil
+func (c *Manager) EtcdClient() *etcd.Client {
+	return c.Client
+}
+
 // changeCallback 处理配置变动，维护 path 对应的各个优先级配置，当高优先级被删除时，替换回低优先级配置
 func (c *Manager) changeCallback(event ChangeEvent) error {
 	history, exists := c.PathHistory[event.Key]
@@ -77,12 +97,35 @@ func (c *Manager) changeCallback(event ChangeEvent) error {
 	// if something happen, keep previews value
 	if err == nil {
 		c.PathHistory[event.Key] = history
+		c.notify(event.Key)
 	}
 
 	return err
 
 }
 
+// OnChange 注册 path 对应的配置更新后的回调, 回调在配置中心的监听协程中执行, 此时绑定的对象已经完成更新
+func (c *Manager) OnChange(path string, callback func()) {
+	c.listenerLock.Lock()
+	defer c.listenerLock.Unlock()
+
+	if c.listeners == nil {
+		c.listeners = make(map[string][]func())
+	}
+	c.listeners[path] = append(c.listeners[path], callback)
+}
+
+// notify 通知 path 的配置已更新
+func (c *Manager) notify(path string) {
+	c.listenerLock.Lock()
+	callbacks := slices.Clone(c.listeners[path])
+	c.listenerLock.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
 func (c *Manager) AddPrefix(prefix string, priority int, ignoreEmpty bool) {
 	if len(c.Instances) == 2 { // TODO: 暂时只支持两层优先级
 		return
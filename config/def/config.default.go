@@ -1,26 +1,38 @@
 package def
 
 import (
+	commondef "github.com/eden-quan/go-biz-kit/common/def"
 	"github.com/eden-quan/go-biz-kit/config"
 )
 
 type Configuration struct {
-	Server       *Server  `conf_path:"/basic/config"`               // 服务的地址配置，包括监听的地址，端口等
-	Registry     Registry `conf_path:"/basic/online"`               // 其他在线服务
-	Profile      Profile  `conf_path:"/basic/profile/config"`       // 性能分析配置
-	Log          Log      `conf_path:"/middleware/log/config"`      // 服务的日志配置
-	Redis        Redis    `conf_path:"/middleware/redis/config"`    // Redis 配置
-	Mongo        Mongo    `conf_path:"/middleware/mongodb/config"`  // MongoDB 配置
-	Database     Database `conf_path:"/middleware/database/config"` // MySQL 配置
-	MessageQueue RabbitMQ `conf_path:"/middleware/rabbitmq/config"` // RabbitMQ 配置
-	Tracing      Tracing  `conf_path:"/middleware/tracing/config"`  // 链路跟踪配置
+	Server       *Server          `conf_path:"/basic/config"`                  // 服务的地址配置，包括监听的地址，端口等
+	Registry     Registry         `conf_path:"/basic/online"`                  // 其他在线服务
+	Profile      Profile          `conf_path:"/basic/profile/config"`          // 性能分析配置
+	Secret       commondef.Secret `conf_path:"/basic/secret"`                  // 服务使用的密钥, 如 JWT 签名密钥
+	Log          Log              `conf_path:"/middleware/log/config"`         // 服务的日志配置
+	Redis        Redis            `conf_path:"/middleware/redis/config"`       // Redis 配置
+	Mongo        Mongo            `conf_path:"/middleware/mongodb/config"`     // MongoDB 配置
+	Database     Database         `conf_path:"/middleware/database/config"`    // MySQL 配置
+	MessageQueue RabbitMQ         `conf_path:"/middleware/rabbitmq/config"`    // RabbitMQ 配置
+	Tracing      Tracing          `conf_path:"/middleware/tracing/config"`     // 链路跟踪配置
+	RateLimit    RateLimit        `conf_path:"/middleware/ratelimit/config"`   // 限流配置
+	Metrics      Metrics          `conf_path:"/middleware/metrics/config"`     // 监控指标配置
+	JWT          JWT              `conf_path:"/middleware/jwt/config"`         // JWT 鉴权配置
+	Policy       Policy           `conf_path:"/middleware/policy/config"`      // 权限策略, YAML 格式
+	Signature    Signature        `conf_path:"/middleware/signature/config"`   // 服务间请求签名的校验配置
+	Idempotency  Idempotency      `conf_path:"/middleware/idempotency/config"` // 幂等配置
+
+	Databases      DatabaseInstances `conf_path:"/middleware/database/instances"` // 多实例数据库配置
+	RedisInstances RedisInstances    `conf_path:"/middleware/redis/instances"`    // 多实例 Redis 配置
+	MongoInstances MongoInstances    `conf_path:"/middleware/mongodb/instances"`  // 多实例 MongoDB 配置
 }
 
 // NewConfiguration 创建一个新的配置实例，该实例支持热更新等能力, 为了保证全局统一，该实例为单例模式
 // 注意：一般情况下业务系统会在基础配置上再添加自定义的配置
 func NewConfiguration(config config.ConfigureWatcherRepo) (*Configuration, error) {
 	conf := &Configuration{}
-	err := config.LoadAndStart(&conf)
+	err := config.LoadAndStart(conf)
 
 	return conf, err
 }
@@ -0,0 +1,77 @@
+package def
+
+import (
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 未匹配任何规则时的处理方式
+const (
+	PolicyDefaultAllow = "allow"
+	PolicyDefaultDeny  = "deny"
+)
+
+// Policy 权限策略, 以 YAML (兼容 JSON) 格式保存在配置中心, 配置更新时整体替换, 解析失败时继续使用之前的策略, 例如:
+//
+//	enable: true
+//	audit: true
+//	default: allow
+//	role_permissions:
+//	  admin: [order.read, order.write]
+//	rules:
+//	  - operation: /api.order.v1.Order/Delete
+//	    roles: [admin]
+//	  - operation: /api.order.v1.Order/*
+//	    permissions: [order.read]
+//	    conditions:
+//	      - field: tenant_id
+//	        equals: $tenant
+type Policy struct {
+	rules atomic.Pointer[PolicyRules]
+}
+
+// PolicyRules 为权限策略的内容
+type PolicyRules struct {
+	Enable          bool                `yaml:"enable" json:"enable"`                     // 是否启用权限校验
+	Audit           bool                `yaml:"audit" json:"audit"`                       // 是否将鉴权结果写入审计日志
+	Default         string              `yaml:"default" json:"default"`                   // 未匹配任何规则时的处理, allow (默认) / deny
+	RolePermissions map[string][]string `yaml:"role_permissions" json:"role_permissions"` // 角色拥有的权限, 用户的权限为令牌中的权限及其角色拥有的权限
+	Rules           []*PolicyRule       `yaml:"rules" json:"rules"`                       // 权限规则, 一个请求需要满足所有匹配的规则
+}
+
+// PolicyRule 接口的权限要求
+type PolicyRule struct {
+	Operation   string             `yaml:"operation" json:"operation"`     // 匹配的 Operation, 以 * 结尾时按前缀匹配, 为空或 * 时匹配所有接口
+	Roles       []string           `yaml:"roles" json:"roles"`             // 需要拥有其中任一角色, 为空时不校验
+	Permissions []string           `yaml:"permissions" json:"permissions"` // 需要拥有全部权限, 为空时不校验
+	Conditions  []*PolicyCondition `yaml:"conditions" json:"conditions"`   // 资源级条件, 需要全部满足
+}
+
+// PolicyCondition 基于请求字段的资源级条件, equals 及 in 中的 $user_id / $tenant 会被替换为当前用户的信息
+type PolicyCondition struct {
+	Field  string   `yaml:"field" json:"field"`   // 请求中的字段, 使用 proto 字段名, 嵌套字段使用 . 分隔, 如 order.tenant_id
+	Equals string   `yaml:"equals" json:"equals"` // 字段需要等于该值
+	In     []string `yaml:"in" json:"in"`         // 字段需要等于其中任一值
+}
+
+// UnmarshalJSON 解析配置中心中的策略, 由于 YAML 兼容 JSON, 因此同时支持两种格式
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	rules := &PolicyRules{}
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return err
+	}
+
+	p.rules.Store(rules)
+	return nil
+}
+
+// Get 返回当前的策略, 未配置时返回 nil
+func (p *Policy) Get() *PolicyRules {
+	return p.rules.Load()
+}
+
+// GetEnable 返回是否启用权限校验
+func (r *PolicyRules) GetEnable() bool {
+	return r != nil && r.Enable
+}
@@ -0,0 +1,22 @@
+package def
+
+import (
+	"time"
+)
+
+// DefaultTimeout 为未配置超时时间时服务端及客户端使用的默认超时时间
+const DefaultTimeout = time.Minute * 5
+
+// OperationTimeout 返回接口 operation 的超时时间, 优先使用 operation_timeouts 中的配置,
+// 未配置时使用 timeout, 均未配置时为 DefaultTimeout
+func (x *Registry) OperationTimeout(operation string) time.Duration {
+	if d, ok := x.GetOperationTimeouts()[operation]; ok && d.AsDuration() > 0 {
+		return d.AsDuration()
+	}
+
+	if x.GetTimeout() != nil && x.GetTimeout().AsDuration() > 0 {
+		return x.GetTimeout().AsDuration()
+	}
+
+	return DefaultTimeout
+}
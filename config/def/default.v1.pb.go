@@ -76,7 +76,7 @@ func (x Log_LogLevelEnum) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Log_LogLevelEnum.Descriptor instead.
 func (Log_LogLevelEnum) EnumDescriptor() ([]byte, []int) {
-	return file_config_def_default_v1_proto_rawDescGZIP(), []int{8, 0}
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{15, 0}
 }
 
 // Server 服务
@@ -89,6 +89,14 @@ type Server struct {
 	Http *Registry `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
 	// grpc grpc服务配置
 	Grpc *Registry `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	// shutdown 服务退出时的优雅关闭配置
+	Shutdown *Shutdown `protobuf:"bytes,3,opt,name=shutdown,proto3" json:"shutdown,omitempty"`
+	// load_shedding 基于 CPU 及并发数的自适应过载保护配置
+	LoadShedding *LoadShedding `protobuf:"bytes,4,opt,name=load_shedding,json=loadShedding,proto3" json:"load_shedding,omitempty"`
+	// discovery 基于 ETCD 的服务注册与发现配置, 本服务的配置用于注册, /registry 下目标服务的配置用于发现
+	Discovery *Discovery `protobuf:"bytes,5,opt,name=discovery,proto3" json:"discovery,omitempty"`
+	// admin 管理端点配置, 提供 pprof、路由列表、依赖图及运行信息
+	Admin *Admin `protobuf:"bytes,6,opt,name=admin,proto3" json:"admin,omitempty"`
 }
 
 func (x *Server) Reset() {
@@ -137,22 +145,45 @@ func (x *Server) GetGrpc() *Registry {
 	return nil
 }
 
-type Tracing struct {
+func (x *Server) GetShutdown() *Shutdown {
+	if x != nil {
+		return x.Shutdown
+	}
+	return nil
+}
+
+func (x *Server) GetLoadShedding() *LoadShedding {
+	if x != nil {
+		return x.LoadShedding
+	}
+	return nil
+}
+
+func (x *Server) GetDiscovery() *Discovery {
+	if x != nil {
+		return x.Discovery
+	}
+	return nil
+}
+
+func (x *Server) GetAdmin() *Admin {
+	if x != nil {
+		return x.Admin
+	}
+	return nil
+}
+
+type Admin struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enable       bool    `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                   // 是否启用链路跟踪
-	Endpoint     string  `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                                // 链路跟踪的 collector 服务地址, 默认为 127.0.0.1:4318
-	UrlPath      string  `protobuf:"bytes,3,opt,name=url_path,json=urlPath,proto3" json:"url_path,omitempty"`                   // 链路跟踪接口 collector 接口路径 , 默认为 /api/traces
-	SimpleRate   float64 `protobuf:"fixed64,4,opt,name=simple_rate,json=simpleRate,proto3" json:"simple_rate,omitempty"`        // 日志采样比例
-	Type         string  `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`                                        // 链路跟踪类型，不启用时使用 memory, 启用时为 jaeger
-	MaxBatchSize int32   `protobuf:"varint,6,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"` // 最大的批量提交数
-	MaxQueueSize int32   `protobuf:"varint,7,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"` // 最大的缓存队列大小
+	Enable  bool   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`  // 是否启用管理端点, 修改后需要重启
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // 管理端点的监听地址, 应与业务服务使用不同的地址且不对外暴露, 默认为 127.0.0.1:9901
 }
 
-func (x *Tracing) Reset() {
-	*x = Tracing{}
+func (x *Admin) Reset() {
+	*x = Admin{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -160,13 +191,13 @@ func (x *Tracing) Reset() {
 	}
 }
 
-func (x *Tracing) String() string {
+func (x *Admin) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tracing) ProtoMessage() {}
+func (*Admin) ProtoMessage() {}
 
-func (x *Tracing) ProtoReflect() protoreflect.Message {
+func (x *Admin) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -178,75 +209,38 @@ func (x *Tracing) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tracing.ProtoReflect.Descriptor instead.
-func (*Tracing) Descriptor() ([]byte, []int) {
+// Deprecated: Use Admin.ProtoReflect.Descriptor instead.
+func (*Admin) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Tracing) GetEnable() bool {
+func (x *Admin) GetEnable() bool {
 	if x != nil {
 		return x.Enable
 	}
 	return false
 }
 
-func (x *Tracing) GetEndpoint() string {
-	if x != nil {
-		return x.Endpoint
-	}
-	return ""
-}
-
-func (x *Tracing) GetUrlPath() string {
-	if x != nil {
-		return x.UrlPath
-	}
-	return ""
-}
-
-func (x *Tracing) GetSimpleRate() float64 {
-	if x != nil {
-		return x.SimpleRate
-	}
-	return 0
-}
-
-func (x *Tracing) GetType() string {
+func (x *Admin) GetAddress() string {
 	if x != nil {
-		return x.Type
+		return x.Address
 	}
 	return ""
 }
 
-func (x *Tracing) GetMaxBatchSize() int32 {
-	if x != nil {
-		return x.MaxBatchSize
-	}
-	return 0
-}
-
-func (x *Tracing) GetMaxQueueSize() int32 {
-	if x != nil {
-		return x.MaxQueueSize
-	}
-	return 0
-}
-
-type Registry struct {
+type Discovery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enable  bool                 `protobuf:"varint,6,opt,name=enable,proto3" json:"enable,omitempty"`  // 是否启用该 Service
-	Name    string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // 服务名
-	Version string               `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // 服务版本
-	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"` // 该服务的超时时间
-	Address string               `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"` // 服务地址 - 一般为域名，服务发现能力由运维环境提供，如果需要私有化部署可配置为服务 IP
-	Type    string               `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`       // 服务类型, grpc/http
+	Enable    bool                 `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`      // 是否启用服务注册与发现, 启用后客户端不再使用 Registry.address, 而是通过 Registry.name 发现服务实例
+	Namespace string               `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"` // 服务实例在 ETCD 中的前缀, 默认为 /microservices
+	Ttl       *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`             // 注册信息的租约时间, 服务异常退出后超过该时间会被自动摘除, 默认为 15s
+	Balancer  string               `protobuf:"bytes,4,opt,name=balancer,proto3" json:"balancer,omitempty"`   // 客户端的负载均衡策略, round_robin: 轮询 (默认), p2c: 基于延迟及负载的 Power of Two Choices
 }
 
-func (x *Registry) Reset() {
-	*x = Registry{}
+func (x *Discovery) Reset() {
+	*x = Discovery{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -254,13 +248,13 @@ func (x *Registry) Reset() {
 	}
 }
 
-func (x *Registry) String() string {
+func (x *Discovery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Registry) ProtoMessage() {}
+func (*Discovery) ProtoMessage() {}
 
-func (x *Registry) ProtoReflect() protoreflect.Message {
+func (x *Discovery) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -272,66 +266,53 @@ func (x *Registry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Registry.ProtoReflect.Descriptor instead.
-func (*Registry) Descriptor() ([]byte, []int) {
+// Deprecated: Use Discovery.ProtoReflect.Descriptor instead.
+func (*Discovery) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Registry) GetEnable() bool {
+func (x *Discovery) GetEnable() bool {
 	if x != nil {
 		return x.Enable
 	}
 	return false
 }
 
-func (x *Registry) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Registry) GetVersion() string {
+func (x *Discovery) GetNamespace() string {
 	if x != nil {
-		return x.Version
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *Registry) GetTimeout() *durationpb.Duration {
+func (x *Discovery) GetTtl() *durationpb.Duration {
 	if x != nil {
-		return x.Timeout
+		return x.Ttl
 	}
 	return nil
 }
 
-func (x *Registry) GetAddress() string {
-	if x != nil {
-		return x.Address
-	}
-	return ""
-}
-
-func (x *Registry) GetType() string {
+func (x *Discovery) GetBalancer() string {
 	if x != nil {
-		return x.Type
+		return x.Balancer
 	}
 	return ""
 }
 
-type Data struct {
+type LoadShedding struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Database *Database `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
-	Redis    *Redis    `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
-	Mongodb  *Mongo    `protobuf:"bytes,3,opt,name=mongodb,proto3" json:"mongodb,omitempty"`
-	RabbitMq *RabbitMQ `protobuf:"bytes,4,opt,name=rabbitMq,proto3" json:"rabbitMq,omitempty"`
+	Enable       bool                 `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                 // 是否启用过载保护, 支持动态开关
+	Window       *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`                                  // 统计的时间窗口, 默认为 10s
+	Bucket       int32                `protobuf:"varint,3,opt,name=bucket,proto3" json:"bucket,omitempty"`                                 // 时间窗口内的桶数, 默认为 100
+	CpuThreshold int64                `protobuf:"varint,4,opt,name=cpu_threshold,json=cpuThreshold,proto3" json:"cpu_threshold,omitempty"` // 触发过载保护的 CPU 使用率 (千分比), 默认为 800
+	CpuQuota     float64              `protobuf:"fixed64,5,opt,name=cpu_quota,json=cpuQuota,proto3" json:"cpu_quota,omitempty"`            // 容器的 CPU 配额, 为 0 时自动获取
 }
 
-func (x *Data) Reset() {
-	*x = Data{}
+func (x *LoadShedding) Reset() {
+	*x = LoadShedding{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -339,13 +320,13 @@ func (x *Data) Reset() {
 	}
 }
 
-func (x *Data) String() string {
+func (x *LoadShedding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data) ProtoMessage() {}
+func (*LoadShedding) ProtoMessage() {}
 
-func (x *Data) ProtoReflect() protoreflect.Message {
+func (x *LoadShedding) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -357,54 +338,58 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data.ProtoReflect.Descriptor instead.
-func (*Data) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoadShedding.ProtoReflect.Descriptor instead.
+func (*LoadShedding) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Data) GetDatabase() *Database {
+func (x *LoadShedding) GetEnable() bool {
 	if x != nil {
-		return x.Database
+		return x.Enable
 	}
-	return nil
+	return false
 }
 
-func (x *Data) GetRedis() *Redis {
+func (x *LoadShedding) GetWindow() *durationpb.Duration {
 	if x != nil {
-		return x.Redis
+		return x.Window
 	}
 	return nil
 }
 
-func (x *Data) GetMongodb() *Mongo {
+func (x *LoadShedding) GetBucket() int32 {
 	if x != nil {
-		return x.Mongodb
+		return x.Bucket
 	}
-	return nil
+	return 0
 }
 
-func (x *Data) GetRabbitMq() *RabbitMQ {
+func (x *LoadShedding) GetCpuThreshold() int64 {
 	if x != nil {
-		return x.RabbitMq
+		return x.CpuThreshold
 	}
-	return nil
+	return 0
 }
 
-type RabbitMQ struct {
+func (x *LoadShedding) GetCpuQuota() float64 {
+	if x != nil {
+		return x.CpuQuota
+	}
+	return 0
+}
+
+type Shutdown struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Addresses  string               `protobuf:"bytes,1,opt,name=addresses,proto3" json:"addresses,omitempty"`                     // 服务的连接地址
-	Vhost      string               `protobuf:"bytes,2,opt,name=vhost,proto3" json:"vhost,omitempty"`                             // 虚拟主机名，后期可用于做隔离
-	Retry      int32                `protobuf:"varint,3,opt,name=retry,proto3" json:"retry,omitempty"`                            // 连接的重试次数
-	ClientName string               `protobuf:"bytes,4,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"` // 客户端标识符
-	Heartbeat  *durationpb.Duration `protobuf:"bytes,5,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`                     // 连接心跳检测间隔
-	Enable     bool                 `protobuf:"varint,6,opt,name=enable,proto3" json:"enable,omitempty"`                          // 是否启用 MQ 功能
+	DrainDelay    *durationpb.Duration `protobuf:"bytes,1,opt,name=drain_delay,json=drainDelay,proto3" json:"drain_delay,omitempty"`          // 就绪状态置为不可用后等待负载均衡感知的时间, 默认为 0
+	Timeout       *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`                                  // 等待处理中请求完成的最长时间, 默认为 30s
+	ReadinessPath string               `protobuf:"bytes,3,opt,name=readiness_path,json=readinessPath,proto3" json:"readiness_path,omitempty"` // HTTP 就绪检查路径，默认为 /ready
 }
 
-func (x *RabbitMQ) Reset() {
-	*x = RabbitMQ{}
+func (x *Shutdown) Reset() {
+	*x = Shutdown{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -412,13 +397,13 @@ func (x *RabbitMQ) Reset() {
 	}
 }
 
-func (x *RabbitMQ) String() string {
+func (x *Shutdown) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RabbitMQ) ProtoMessage() {}
+func (*Shutdown) ProtoMessage() {}
 
-func (x *RabbitMQ) ProtoReflect() protoreflect.Message {
+func (x *Shutdown) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -430,66 +415,48 @@ func (x *RabbitMQ) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RabbitMQ.ProtoReflect.Descriptor instead.
-func (*RabbitMQ) Descriptor() ([]byte, []int) {
+// Deprecated: Use Shutdown.ProtoReflect.Descriptor instead.
+func (*Shutdown) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RabbitMQ) GetAddresses() string {
-	if x != nil {
-		return x.Addresses
-	}
-	return ""
-}
-
-func (x *RabbitMQ) GetVhost() string {
-	if x != nil {
-		return x.Vhost
-	}
-	return ""
-}
-
-func (x *RabbitMQ) GetRetry() int32 {
-	if x != nil {
-		return x.Retry
-	}
-	return 0
-}
-
-func (x *RabbitMQ) GetClientName() string {
+func (x *Shutdown) GetDrainDelay() *durationpb.Duration {
 	if x != nil {
-		return x.ClientName
+		return x.DrainDelay
 	}
-	return ""
+	return nil
 }
 
-func (x *RabbitMQ) GetHeartbeat() *durationpb.Duration {
+func (x *Shutdown) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Heartbeat
+		return x.Timeout
 	}
 	return nil
 }
 
-func (x *RabbitMQ) GetEnable() bool {
+func (x *Shutdown) GetReadinessPath() string {
 	if x != nil {
-		return x.Enable
+		return x.ReadinessPath
 	}
-	return false
+	return ""
 }
 
-type ExchangeConfig struct {
+type Tracing struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                // 交换机名称
-	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                                // 交换机类型，direct/fanout/topic/header , 一般只需要用到前三者
-	Durable    bool   `protobuf:"varint,3,opt,name=durable,proto3" json:"durable,omitempty"`                         // 交换机是否持久化, 持久化的交换机在重启后不会丢失
-	AutoDelete bool   `protobuf:"varint,4,opt,name=auto_delete,json=autoDelete,proto3" json:"auto_delete,omitempty"` // 交换机的是否会在消费后自动删除, 设置为 false 的话能够更好的保存数据，并在全局层面进行管理
+	Enable       bool    `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                   // 是否启用链路跟踪
+	Endpoint     string  `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                                // 链路跟踪的 collector 服务地址, 默认为 127.0.0.1:4318
+	UrlPath      string  `protobuf:"bytes,3,opt,name=url_path,json=urlPath,proto3" json:"url_path,omitempty"`                   // 链路跟踪接口 collector 接口路径 , 默认为 /api/traces
+	SimpleRate   float64 `protobuf:"fixed64,4,opt,name=simple_rate,json=simpleRate,proto3" json:"simple_rate,omitempty"`        // 日志采样比例
+	Type         string  `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`                                        // 链路跟踪类型，不启用时使用 memory, 启用时为 jaeger
+	MaxBatchSize int32   `protobuf:"varint,6,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"` // 最大的批量提交数
+	MaxQueueSize int32   `protobuf:"varint,7,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"` // 最大的缓存队列大小
 }
 
-func (x *ExchangeConfig) Reset() {
-	*x = ExchangeConfig{}
+func (x *Tracing) Reset() {
+	*x = Tracing{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -497,13 +464,13 @@ func (x *ExchangeConfig) Reset() {
 	}
 }
 
-func (x *ExchangeConfig) String() string {
+func (x *Tracing) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExchangeConfig) ProtoMessage() {}
+func (*Tracing) ProtoMessage() {}
 
-func (x *ExchangeConfig) ProtoReflect() protoreflect.Message {
+func (x *Tracing) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -515,53 +482,79 @@ func (x *ExchangeConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExchangeConfig.ProtoReflect.Descriptor instead.
-func (*ExchangeConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use Tracing.ProtoReflect.Descriptor instead.
+func (*Tracing) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ExchangeConfig) GetName() string {
+func (x *Tracing) GetEnable() bool {
 	if x != nil {
-		return x.Name
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Tracing) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
 	}
 	return ""
 }
 
-func (x *ExchangeConfig) GetKind() string {
+func (x *Tracing) GetUrlPath() string {
 	if x != nil {
-		return x.Kind
+		return x.UrlPath
 	}
 	return ""
 }
 
-func (x *ExchangeConfig) GetDurable() bool {
+func (x *Tracing) GetSimpleRate() float64 {
 	if x != nil {
-		return x.Durable
+		return x.SimpleRate
 	}
-	return false
+	return 0
 }
 
-func (x *ExchangeConfig) GetAutoDelete() bool {
+func (x *Tracing) GetType() string {
 	if x != nil {
-		return x.AutoDelete
+		return x.Type
 	}
-	return false
+	return ""
 }
 
-type QueueConfig struct {
+func (x *Tracing) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *Tracing) GetMaxQueueSize() int32 {
+	if x != nil {
+		return x.MaxQueueSize
+	}
+	return 0
+}
+
+type Registry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // 消息名称
-	ExchangeName string `protobuf:"bytes,2,opt,name=exchange_name,json=exchangeName,proto3" json:"exchange_name,omitempty"` // 使用的交换机名称, 一般可保持与消息名一致
-	Durable      bool   `protobuf:"varint,3,opt,name=durable,proto3" json:"durable,omitempty"`                              // 队列是否持久化，持久化的队列在重启后不会消失
-	AutoDelete   bool   `protobuf:"varint,4,opt,name=auto_delete,json=autoDelete,proto3" json:"auto_delete,omitempty"`      // 队列的是否会在消费后自动删除, 设置为 false 的话能够更好的保存数据，并在全局层面进行管理
-	RoutingKey   string `protobuf:"bytes,5,opt,name=routing_key,json=routingKey,proto3" json:"routing_key,omitempty"`       // 队列的路由字段，通过该字段与交换机建立绑定，后续交换机收到消息时会将消息送给匹配路由的队列
+	Enable            bool                            `protobuf:"varint,6,opt,name=enable,proto3" json:"enable,omitempty"`                                                                                                                                       // 是否启用该 Service
+	Name              string                          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                                                                            // 服务名
+	Version           string                          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                                                                                                                      // 服务版本
+	Timeout           *durationpb.Duration            `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`                                                                                                                                      // 该服务的超时时间
+	Address           string                          `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`                                                                                                                                      // 服务地址 - 一般为域名，服务发现能力由运维环境提供，如果需要私有化部署可配置为服务 IP
+	Type              string                          `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`                                                                                                                                            // 服务类型, grpc/http
+	OperationTimeouts map[string]*durationpb.Duration `protobuf:"bytes,7,rep,name=operation_timeouts,json=operationTimeouts,proto3" json:"operation_timeouts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 单个接口的超时时间, key 为接口的 Operation, 如 /api.user.v1.User/Export, 未配置的接口使用 timeout
+	CircuitBreaker    *CircuitBreaker                 `protobuf:"bytes,8,opt,name=circuit_breaker,json=circuitBreaker,proto3" json:"circuit_breaker,omitempty"`                                                                                                  // 调用该服务时使用的熔断配置
+	Retry             *Retry                          `protobuf:"bytes,9,opt,name=retry,proto3" json:"retry,omitempty"`                                                                                                                                          // 调用该服务时使用的重试配置
+	Tls               *TLS                            `protobuf:"bytes,10,opt,name=tls,proto3" json:"tls,omitempty"`                                                                                                                                             // TLS 配置, 在 /basic/config 中用于服务端, 在 /registry 中用于调用该服务的客户端
 }
 
-func (x *QueueConfig) Reset() {
-	*x = QueueConfig{}
+func (x *Registry) Reset() {
+	*x = Registry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -569,13 +562,13 @@ func (x *QueueConfig) Reset() {
 	}
 }
 
-func (x *QueueConfig) String() string {
+func (x *Registry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueueConfig) ProtoMessage() {}
+func (*Registry) ProtoMessage() {}
 
-func (x *QueueConfig) ProtoReflect() protoreflect.Message {
+func (x *Registry) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -587,60 +580,100 @@ func (x *QueueConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueueConfig.ProtoReflect.Descriptor instead.
-func (*QueueConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use Registry.ProtoReflect.Descriptor instead.
+func (*Registry) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *QueueConfig) GetName() string {
+func (x *Registry) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Registry) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *QueueConfig) GetExchangeName() string {
+func (x *Registry) GetVersion() string {
 	if x != nil {
-		return x.ExchangeName
+		return x.Version
 	}
 	return ""
 }
 
-func (x *QueueConfig) GetDurable() bool {
+func (x *Registry) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Durable
+		return x.Timeout
 	}
-	return false
+	return nil
 }
 
-func (x *QueueConfig) GetAutoDelete() bool {
+func (x *Registry) GetAddress() string {
 	if x != nil {
-		return x.AutoDelete
+		return x.Address
 	}
-	return false
+	return ""
 }
 
-func (x *QueueConfig) GetRoutingKey() string {
+func (x *Registry) GetType() string {
 	if x != nil {
-		return x.RoutingKey
+		return x.Type
 	}
 	return ""
 }
 
-type ConsumeConfig struct {
+func (x *Registry) GetOperationTimeouts() map[string]*durationpb.Duration {
+	if x != nil {
+		return x.OperationTimeouts
+	}
+	return nil
+}
+
+func (x *Registry) GetCircuitBreaker() *CircuitBreaker {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return nil
+}
+
+func (x *Registry) GetRetry() *Retry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *Registry) GetTls() *TLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+// TLS 证书配置, 证书可以通过文件路径或 PEM 内容配置, 同时配置时优先使用 PEM 内容, 文件或配置变化后无需重启即可生效
+type TLS struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name             string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                    // 消费者的名称
-	AutoAck          bool   `protobuf:"varint,2,opt,name=auto_ack,json=autoAck,proto3" json:"auto_ack,omitempty"`                              // 是否自动提交 ACK
-	NoWait           bool   `protobuf:"varint,3,opt,name=no_wait,json=noWait,proto3" json:"no_wait,omitempty"`                                 // 是否不等待连接完成, 一般设置为 false
-	QosPrefetchSize  int32  `protobuf:"varint,4,opt,name=qos_prefetch_size,json=qosPrefetchSize,proto3" json:"qos_prefetch_size,omitempty"`    // 允许缓冲在内存的消息数，默认为 0, 只有在存在大量消息需要消费时使用，用于提高吞吐量
-	QosPrefetchCount int32  `protobuf:"varint,5,opt,name=qos_prefetch_count,json=qosPrefetchCount,proto3" json:"qos_prefetch_count,omitempty"` // 允许缓冲在内存的字节数，默认为 0，一般不做配置
+	Enable            bool   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                                  // 是否启用 TLS
+	CertFile          string `protobuf:"bytes,2,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`                               // 证书文件路径, 服务端为服务证书, 客户端为 mTLS 使用的客户端证书
+	KeyFile           string `protobuf:"bytes,3,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`                                  // 私钥文件路径
+	CaFile            string `protobuf:"bytes,4,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`                                     // CA 证书文件路径, 服务端用于校验客户端证书, 客户端用于校验服务端证书, 未配置时客户端使用系统 CA
+	CertPem           string `protobuf:"bytes,5,opt,name=cert_pem,json=certPem,proto3" json:"cert_pem,omitempty"`                                  // PEM 格式的证书内容
+	KeyPem            string `protobuf:"bytes,6,opt,name=key_pem,json=keyPem,proto3" json:"key_pem,omitempty"`                                     // PEM 格式的私钥内容
+	CaPem             string `protobuf:"bytes,7,opt,name=ca_pem,json=caPem,proto3" json:"ca_pem,omitempty"`                                        // PEM 格式的 CA 证书内容
+	RequireClientCert bool   `protobuf:"varint,8,opt,name=require_client_cert,json=requireClientCert,proto3" json:"require_client_cert,omitempty"` // 服务端: 是否要求客户端提供由 CA 签发的证书 (mTLS)
+	ServerName        string `protobuf:"bytes,9,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`                         // 客户端: 校验服务端证书时使用的域名, 默认为连接地址中的域名或 IP (IP 时校验证书中的 IP), 使用服务发现时必须配置
 }
 
-func (x *ConsumeConfig) Reset() {
-	*x = ConsumeConfig{}
+func (x *TLS) Reset() {
+	*x = TLS{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -648,13 +681,13 @@ func (x *ConsumeConfig) Reset() {
 	}
 }
 
-func (x *ConsumeConfig) String() string {
+func (x *TLS) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConsumeConfig) ProtoMessage() {}
+func (*TLS) ProtoMessage() {}
 
-func (x *ConsumeConfig) ProtoReflect() protoreflect.Message {
+func (x *TLS) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -666,62 +699,91 @@ func (x *ConsumeConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConsumeConfig.ProtoReflect.Descriptor instead.
-func (*ConsumeConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use TLS.ProtoReflect.Descriptor instead.
+func (*TLS) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ConsumeConfig) GetName() string {
+func (x *TLS) GetEnable() bool {
 	if x != nil {
-		return x.Name
+		return x.Enable
+	}
+	return false
+}
+
+func (x *TLS) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
 	}
 	return ""
 }
 
-func (x *ConsumeConfig) GetAutoAck() bool {
+func (x *TLS) GetKeyFile() string {
 	if x != nil {
-		return x.AutoAck
+		return x.KeyFile
 	}
-	return false
+	return ""
 }
 
-func (x *ConsumeConfig) GetNoWait() bool {
+func (x *TLS) GetCaFile() string {
 	if x != nil {
-		return x.NoWait
+		return x.CaFile
 	}
-	return false
+	return ""
 }
 
-func (x *ConsumeConfig) GetQosPrefetchSize() int32 {
+func (x *TLS) GetCertPem() string {
 	if x != nil {
-		return x.QosPrefetchSize
+		return x.CertPem
 	}
-	return 0
+	return ""
 }
 
-func (x *ConsumeConfig) GetQosPrefetchCount() int32 {
+func (x *TLS) GetKeyPem() string {
 	if x != nil {
-		return x.QosPrefetchCount
+		return x.KeyPem
 	}
-	return 0
+	return ""
 }
 
-// Log 日志
-type Log struct {
+func (x *TLS) GetCaPem() string {
+	if x != nil {
+		return x.CaPem
+	}
+	return ""
+}
+
+func (x *TLS) GetRequireClientCert() bool {
+	if x != nil {
+		return x.RequireClientCert
+	}
+	return false
+}
+
+func (x *TLS) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+// Retry 客户端的重试配置, 只有幂等的接口会进行重试, 接口的幂等性可以通过 idempotent_operations
+// 或 proto 中的 option idempotency_level = IDEMPOTENT / NO_SIDE_EFFECTS 声明
+type Retry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Console 输出到控制台
-	Console *Log_Console `protobuf:"bytes,1,opt,name=console,proto3" json:"console,omitempty"`
-	// Graylog 输出到文件
-	Graylog *Log_Graylog `protobuf:"bytes,2,opt,name=graylog,proto3" json:"graylog,omitempty"`
-	// File 输出到文件
-	File *Log_File `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	Enable               bool                 `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                                        // 是否启用重试
+	MaxAttempts          int32                `protobuf:"varint,2,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`                           // 最大的调用次数 (包含第一次调用), 默认为 3
+	InitialBackoff       *durationpb.Duration `protobuf:"bytes,3,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`                   // 第一次重试前的等待时间, 默认为 100ms
+	MaxBackoff           *durationpb.Duration `protobuf:"bytes,4,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`                               // 重试的最大等待时间, 默认为 2s
+	Multiplier           float64              `protobuf:"fixed64,5,opt,name=multiplier,proto3" json:"multiplier,omitempty"`                                               // 每次重试等待时间的增长倍数, 默认为 2
+	IdempotentOperations []string             `protobuf:"bytes,6,rep,name=idempotent_operations,json=idempotentOperations,proto3" json:"idempotent_operations,omitempty"` // 幂等的接口, 如 /api.user.v1.User/GetUser, 以 * 结尾时按前缀匹配
 }
 
-func (x *Log) Reset() {
-	*x = Log{}
+func (x *Retry) Reset() {
+	*x = Retry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -729,13 +791,13 @@ func (x *Log) Reset() {
 	}
 }
 
-func (x *Log) String() string {
+func (x *Retry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Log) ProtoMessage() {}
+func (*Retry) ProtoMessage() {}
 
-func (x *Log) ProtoReflect() protoreflect.Message {
+func (x *Retry) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -747,53 +809,71 @@ func (x *Log) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Log.ProtoReflect.Descriptor instead.
-func (*Log) Descriptor() ([]byte, []int) {
+// Deprecated: Use Retry.ProtoReflect.Descriptor instead.
+func (*Retry) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Log) GetConsole() *Log_Console {
+func (x *Retry) GetEnable() bool {
 	if x != nil {
-		return x.Console
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Retry) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Retry) GetInitialBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.InitialBackoff
 	}
 	return nil
 }
 
-func (x *Log) GetGraylog() *Log_Graylog {
+func (x *Retry) GetMaxBackoff() *durationpb.Duration {
 	if x != nil {
-		return x.Graylog
+		return x.MaxBackoff
 	}
 	return nil
 }
 
-func (x *Log) GetFile() *Log_File {
+func (x *Retry) GetMultiplier() float64 {
 	if x != nil {
-		return x.File
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *Retry) GetIdempotentOperations() []string {
+	if x != nil {
+		return x.IdempotentOperations
 	}
 	return nil
 }
 
-type Redis struct {
+// CircuitBreaker 客户端的熔断配置, 每个接口使用独立的熔断状态
+type CircuitBreaker struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enable          bool                 `protobuf:"varint,111,opt,name=enable,proto3" json:"enable,omitempty"`
-	Addresses       []string             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`                                          // Redis 节点地址
-	Db              int32                `protobuf:"varint,2,opt,name=db,proto3" json:"db,omitempty"`                                                       // 使用的数据库
-	Username        string               `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`                                            // 用户名
-	Password        string               `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`                                            // 密码
-	ReadTimeout     *durationpb.Duration `protobuf:"bytes,5,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`                   // 读取超时时间
-	WriteTimeout    *durationpb.Duration `protobuf:"bytes,6,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`                // 写入超时时间
-	DialTimeout     *durationpb.Duration `protobuf:"bytes,7,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`                   // 连接超时时间
-	MaxPoolSize     uint64               `protobuf:"varint,8,opt,name=max_pool_size,json=maxPoolSize,proto3" json:"max_pool_size,omitempty"`                // 最大的连接池数
-	MaxPoolIdleSize uint64               `protobuf:"varint,9,opt,name=max_pool_idle_size,json=maxPoolIdleSize,proto3" json:"max_pool_idle_size,omitempty"`  // 最大连接数
-	MinPoolIdleSize uint64               `protobuf:"varint,10,opt,name=min_pool_idle_size,json=minPoolIdleSize,proto3" json:"min_pool_idle_size,omitempty"` // 最小空闲链接
-	MaxRetries      uint64               `protobuf:"varint,11,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`                    // 最大重试次数
+	Enable      bool                 `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                             // 是否启用熔断
+	Type        string               `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                  // 熔断策略, sre: 基于成功率的自适应熔断 (默认), consecutive: 基于连续失败次数的熔断
+	Success     float64              `protobuf:"fixed64,3,opt,name=success,proto3" json:"success,omitempty"`                          // sre: 成功率阈值, 默认为 0.6
+	Request     int64                `protobuf:"varint,4,opt,name=request,proto3" json:"request,omitempty"`                           // sre: 触发熔断的最小请求数, 默认为 100
+	Bucket      int32                `protobuf:"varint,5,opt,name=bucket,proto3" json:"bucket,omitempty"`                             // sre: 统计窗口内的桶数, 默认为 10
+	Window      *durationpb.Duration `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`                              // sre: 统计窗口, 默认为 3s
+	Failures    int32                `protobuf:"varint,7,opt,name=failures,proto3" json:"failures,omitempty"`                         // consecutive: 触发熔断的连续失败次数, 默认为 5
+	OpenTimeout *durationpb.Duration `protobuf:"bytes,8,opt,name=open_timeout,json=openTimeout,proto3" json:"open_timeout,omitempty"` // consecutive: 熔断后允许尝试请求的等待时间, 默认为 10s
 }
 
-func (x *Redis) Reset() {
-	*x = Redis{}
+func (x *CircuitBreaker) Reset() {
+	*x = CircuitBreaker{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_config_def_default_v1_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -801,13 +881,13 @@ func (x *Redis) Reset() {
 	}
 }
 
-func (x *Redis) String() string {
+func (x *CircuitBreaker) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Redis) ProtoMessage() {}
+func (*CircuitBreaker) ProtoMessage() {}
 
-func (x *Redis) ProtoReflect() protoreflect.Message {
+func (x *CircuitBreaker) ProtoReflect() protoreflect.Message {
 	mi := &file_config_def_default_v1_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -819,131 +899,1223 @@ func (x *Redis) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Redis.ProtoReflect.Descriptor instead.
-func (*Redis) Descriptor() ([]byte, []int) {
+// Deprecated: Use CircuitBreaker.ProtoReflect.Descriptor instead.
+func (*CircuitBreaker) Descriptor() ([]byte, []int) {
 	return file_config_def_default_v1_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Redis) GetEnable() bool {
+func (x *CircuitBreaker) GetEnable() bool {
 	if x != nil {
 		return x.Enable
 	}
 	return false
 }
 
-func (x *Redis) GetAddresses() []string {
+func (x *CircuitBreaker) GetType() string {
 	if x != nil {
-		return x.Addresses
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-func (x *Redis) GetDb() int32 {
+func (x *CircuitBreaker) GetSuccess() float64 {
 	if x != nil {
-		return x.Db
+		return x.Success
 	}
 	return 0
 }
 
-func (x *Redis) GetUsername() string {
+func (x *CircuitBreaker) GetRequest() int64 {
 	if x != nil {
-		return x.Username
+		return x.Request
 	}
-	return ""
+	return 0
 }
 
-func (x *Redis) GetPassword() string {
+func (x *CircuitBreaker) GetBucket() int32 {
 	if x != nil {
-		return x.Password
+		return x.Bucket
 	}
-	return ""
+	return 0
 }
 
-func (x *Redis) GetReadTimeout() *durationpb.Duration {
+func (x *CircuitBreaker) GetWindow() *durationpb.Duration {
 	if x != nil {
-		return x.ReadTimeout
+		return x.Window
 	}
 	return nil
 }
 
-func (x *Redis) GetWriteTimeout() *durationpb.Duration {
+func (x *CircuitBreaker) GetFailures() int32 {
 	if x != nil {
-		return x.WriteTimeout
+		return x.Failures
 	}
-	return nil
+	return 0
 }
 
-func (x *Redis) GetDialTimeout() *durationpb.Duration {
+func (x *CircuitBreaker) GetOpenTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.DialTimeout
+		return x.OpenTimeout
 	}
 	return nil
 }
 
-func (x *Redis) GetMaxPoolSize() uint64 {
-	if x != nil {
-		return x.MaxPoolSize
+type Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Database *Database `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	Redis    *Redis    `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
+	Mongodb  *Mongo    `protobuf:"bytes,3,opt,name=mongodb,proto3" json:"mongodb,omitempty"`
+	RabbitMq *RabbitMQ `protobuf:"bytes,4,opt,name=rabbitMq,proto3" json:"rabbitMq,omitempty"`
+}
+
+func (x *Data) Reset() {
+	*x = Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Redis) GetMaxPoolIdleSize() uint64 {
-	if x != nil {
-		return x.MaxPoolIdleSize
+func (x *Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data) ProtoMessage() {}
+
+func (x *Data) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Redis) GetMinPoolIdleSize() uint64 {
+// Deprecated: Use Data.ProtoReflect.Descriptor instead.
+func (*Data) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Data) GetDatabase() *Database {
 	if x != nil {
-		return x.MinPoolIdleSize
+		return x.Database
 	}
-	return 0
+	return nil
 }
 
-func (x *Redis) GetMaxRetries() uint64 {
+func (x *Data) GetRedis() *Redis {
 	if x != nil {
-		return x.MaxRetries
+		return x.Redis
 	}
-	return 0
+	return nil
 }
 
-type Mongo struct {
+func (x *Data) GetMongodb() *Mongo {
+	if x != nil {
+		return x.Mongodb
+	}
+	return nil
+}
+
+func (x *Data) GetRabbitMq() *RabbitMQ {
+	if x != nil {
+		return x.RabbitMq
+	}
+	return nil
+}
+
+type RabbitMQ struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses  string               `protobuf:"bytes,1,opt,name=addresses,proto3" json:"addresses,omitempty"`                     // 服务的连接地址
+	Vhost      string               `protobuf:"bytes,2,opt,name=vhost,proto3" json:"vhost,omitempty"`                             // 虚拟主机名，后期可用于做隔离
+	Retry      int32                `protobuf:"varint,3,opt,name=retry,proto3" json:"retry,omitempty"`                            // 连接的重试次数
+	ClientName string               `protobuf:"bytes,4,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"` // 客户端标识符
+	Heartbeat  *durationpb.Duration `protobuf:"bytes,5,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`                     // 连接心跳检测间隔
+	Enable     bool                 `protobuf:"varint,6,opt,name=enable,proto3" json:"enable,omitempty"`                          // 是否启用 MQ 功能
+}
+
+func (x *RabbitMQ) Reset() {
+	*x = RabbitMQ{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RabbitMQ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RabbitMQ) ProtoMessage() {}
+
+func (x *RabbitMQ) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RabbitMQ.ProtoReflect.Descriptor instead.
+func (*RabbitMQ) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RabbitMQ) GetAddresses() string {
+	if x != nil {
+		return x.Addresses
+	}
+	return ""
+}
+
+func (x *RabbitMQ) GetVhost() string {
+	if x != nil {
+		return x.Vhost
+	}
+	return ""
+}
+
+func (x *RabbitMQ) GetRetry() int32 {
+	if x != nil {
+		return x.Retry
+	}
+	return 0
+}
+
+func (x *RabbitMQ) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *RabbitMQ) GetHeartbeat() *durationpb.Duration {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (x *RabbitMQ) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+type ExchangeConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                // 交换机名称
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                                // 交换机类型，direct/fanout/topic/header , 一般只需要用到前三者
+	Durable    bool   `protobuf:"varint,3,opt,name=durable,proto3" json:"durable,omitempty"`                         // 交换机是否持久化, 持久化的交换机在重启后不会丢失
+	AutoDelete bool   `protobuf:"varint,4,opt,name=auto_delete,json=autoDelete,proto3" json:"auto_delete,omitempty"` // 交换机的是否会在消费后自动删除, 设置为 false 的话能够更好的保存数据，并在全局层面进行管理
+}
+
+func (x *ExchangeConfig) Reset() {
+	*x = ExchangeConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExchangeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeConfig) ProtoMessage() {}
+
+func (x *ExchangeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeConfig.ProtoReflect.Descriptor instead.
+func (*ExchangeConfig) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExchangeConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExchangeConfig) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ExchangeConfig) GetDurable() bool {
+	if x != nil {
+		return x.Durable
+	}
+	return false
+}
+
+func (x *ExchangeConfig) GetAutoDelete() bool {
+	if x != nil {
+		return x.AutoDelete
+	}
+	return false
+}
+
+type QueueConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // 消息名称
+	ExchangeName string `protobuf:"bytes,2,opt,name=exchange_name,json=exchangeName,proto3" json:"exchange_name,omitempty"` // 使用的交换机名称, 一般可保持与消息名一致
+	Durable      bool   `protobuf:"varint,3,opt,name=durable,proto3" json:"durable,omitempty"`                              // 队列是否持久化，持久化的队列在重启后不会消失
+	AutoDelete   bool   `protobuf:"varint,4,opt,name=auto_delete,json=autoDelete,proto3" json:"auto_delete,omitempty"`      // 队列的是否会在消费后自动删除, 设置为 false 的话能够更好的保存数据，并在全局层面进行管理
+	RoutingKey   string `protobuf:"bytes,5,opt,name=routing_key,json=routingKey,proto3" json:"routing_key,omitempty"`       // 队列的路由字段，通过该字段与交换机建立绑定，后续交换机收到消息时会将消息送给匹配路由的队列
+}
+
+func (x *QueueConfig) Reset() {
+	*x = QueueConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueConfig) ProtoMessage() {}
+
+func (x *QueueConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueConfig.ProtoReflect.Descriptor instead.
+func (*QueueConfig) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QueueConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueueConfig) GetExchangeName() string {
+	if x != nil {
+		return x.ExchangeName
+	}
+	return ""
+}
+
+func (x *QueueConfig) GetDurable() bool {
+	if x != nil {
+		return x.Durable
+	}
+	return false
+}
+
+func (x *QueueConfig) GetAutoDelete() bool {
+	if x != nil {
+		return x.AutoDelete
+	}
+	return false
+}
+
+func (x *QueueConfig) GetRoutingKey() string {
+	if x != nil {
+		return x.RoutingKey
+	}
+	return ""
+}
+
+type ConsumeConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name             string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                    // 消费者的名称
+	AutoAck          bool   `protobuf:"varint,2,opt,name=auto_ack,json=autoAck,proto3" json:"auto_ack,omitempty"`                              // 是否自动提交 ACK
+	NoWait           bool   `protobuf:"varint,3,opt,name=no_wait,json=noWait,proto3" json:"no_wait,omitempty"`                                 // 是否不等待连接完成, 一般设置为 false
+	QosPrefetchSize  int32  `protobuf:"varint,4,opt,name=qos_prefetch_size,json=qosPrefetchSize,proto3" json:"qos_prefetch_size,omitempty"`    // 允许缓冲在内存的消息数，默认为 0, 只有在存在大量消息需要消费时使用，用于提高吞吐量
+	QosPrefetchCount int32  `protobuf:"varint,5,opt,name=qos_prefetch_count,json=qosPrefetchCount,proto3" json:"qos_prefetch_count,omitempty"` // 允许缓冲在内存的字节数，默认为 0，一般不做配置
+}
+
+func (x *ConsumeConfig) Reset() {
+	*x = ConsumeConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsumeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeConfig) ProtoMessage() {}
+
+func (x *ConsumeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeConfig.ProtoReflect.Descriptor instead.
+func (*ConsumeConfig) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConsumeConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConsumeConfig) GetAutoAck() bool {
+	if x != nil {
+		return x.AutoAck
+	}
+	return false
+}
+
+func (x *ConsumeConfig) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+func (x *ConsumeConfig) GetQosPrefetchSize() int32 {
+	if x != nil {
+		return x.QosPrefetchSize
+	}
+	return 0
+}
+
+func (x *ConsumeConfig) GetQosPrefetchCount() int32 {
+	if x != nil {
+		return x.QosPrefetchCount
+	}
+	return 0
+}
+
+// Log 日志
+type Log struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Console 输出到控制台
+	Console *Log_Console `protobuf:"bytes,1,opt,name=console,proto3" json:"console,omitempty"`
+	// Graylog 输出到文件
+	Graylog *Log_Graylog `protobuf:"bytes,2,opt,name=graylog,proto3" json:"graylog,omitempty"`
+	// File 输出到文件
+	File *Log_File `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	// fx_level 依赖注入 (fx) 内部事件的最低输出级别 (debug/info/warn/error), 默认为 info
+	FxLevel string `protobuf:"bytes,4,opt,name=fx_level,json=fxLevel,proto3" json:"fx_level,omitempty"`
+	// payload 请求及响应内容的日志配置
+	Payload *Log_Payload `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *Log) Reset() {
+	*x = Log{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log) ProtoMessage() {}
+
+func (x *Log) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log.ProtoReflect.Descriptor instead.
+func (*Log) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Log) GetConsole() *Log_Console {
+	if x != nil {
+		return x.Console
+	}
+	return nil
+}
+
+func (x *Log) GetGraylog() *Log_Graylog {
+	if x != nil {
+		return x.Graylog
+	}
+	return nil
+}
+
+func (x *Log) GetFile() *Log_File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *Log) GetFxLevel() string {
+	if x != nil {
+		return x.FxLevel
+	}
+	return ""
+}
+
+func (x *Log) GetPayload() *Log_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type Redis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable          bool                 `protobuf:"varint,111,opt,name=enable,proto3" json:"enable,omitempty"`
+	Addresses       []string             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`                                          // Redis 节点地址
+	Db              int32                `protobuf:"varint,2,opt,name=db,proto3" json:"db,omitempty"`                                                       // 使用的数据库
+	Username        string               `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`                                            // 用户名
+	Password        string               `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`                                            // 密码
+	ReadTimeout     *durationpb.Duration `protobuf:"bytes,5,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`                   // 读取超时时间
+	WriteTimeout    *durationpb.Duration `protobuf:"bytes,6,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`                // 写入超时时间
+	DialTimeout     *durationpb.Duration `protobuf:"bytes,7,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`                   // 连接超时时间
+	MaxPoolSize     uint64               `protobuf:"varint,8,opt,name=max_pool_size,json=maxPoolSize,proto3" json:"max_pool_size,omitempty"`                // 最大的连接池数
+	MaxPoolIdleSize uint64               `protobuf:"varint,9,opt,name=max_pool_idle_size,json=maxPoolIdleSize,proto3" json:"max_pool_idle_size,omitempty"`  // 最大连接数
+	MinPoolIdleSize uint64               `protobuf:"varint,10,opt,name=min_pool_idle_size,json=minPoolIdleSize,proto3" json:"min_pool_idle_size,omitempty"` // 最小空闲链接
+	MaxRetries      uint64               `protobuf:"varint,11,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`                    // 最大重试次数
+}
+
+func (x *Redis) Reset() {
+	*x = Redis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Redis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Redis) ProtoMessage() {}
+
+func (x *Redis) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Redis.ProtoReflect.Descriptor instead.
+func (*Redis) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Redis) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Redis) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Redis) GetDb() int32 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *Redis) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Redis) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Redis) GetReadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ReadTimeout
+	}
+	return nil
+}
+
+func (x *Redis) GetWriteTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.WriteTimeout
+	}
+	return nil
+}
+
+func (x *Redis) GetDialTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DialTimeout
+	}
+	return nil
+}
+
+func (x *Redis) GetMaxPoolSize() uint64 {
+	if x != nil {
+		return x.MaxPoolSize
+	}
+	return 0
+}
+
+func (x *Redis) GetMaxPoolIdleSize() uint64 {
+	if x != nil {
+		return x.MaxPoolIdleSize
+	}
+	return 0
+}
+
+func (x *Redis) GetMinPoolIdleSize() uint64 {
+	if x != nil {
+		return x.MinPoolIdleSize
+	}
+	return 0
+}
+
+func (x *Redis) GetMaxRetries() uint64 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+type Mongo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable            bool                 `protobuf:"varint,14,opt,name=enable,proto3" json:"enable,omitempty"`
+	Address           string               `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`                                              // MongoDB 的连接字符串，支持集群的形式
+	Hosts             []string             `protobuf:"bytes,3,rep,name=hosts,proto3" json:"hosts,omitempty"`                                                  // MongoDB 的集群地址列表
+	Database          string               `protobuf:"bytes,4,opt,name=database,proto3" json:"database,omitempty"`                                            // 数据库名称
+	MaxPoolSize       uint64               `protobuf:"varint,5,opt,name=max_pool_size,json=maxPoolSize,proto3" json:"max_pool_size,omitempty"`                // 最大空闲连接
+	MinPoolSize       uint64               `protobuf:"varint,6,opt,name=min_pool_size,json=minPoolSize,proto3" json:"min_pool_size,omitempty"`                // 最小空闲连接
+	MaxConnection     uint64               `protobuf:"varint,7,opt,name=max_connection,json=maxConnection,proto3" json:"max_connection,omitempty"`            // 最大连接数
+	ConnectTimeout    *durationpb.Duration `protobuf:"bytes,8,opt,name=connect_timeout,json=connectTimeout,proto3" json:"connect_timeout,omitempty"`          // 连接超时时间
+	HeartbeatInterval *durationpb.Duration `protobuf:"bytes,9,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"` // 心跳间隔
+	MaxConnIdleTime   *durationpb.Duration `protobuf:"bytes,10,opt,name=max_conn_idle_time,json=maxConnIdleTime,proto3" json:"max_conn_idle_time,omitempty"`
+	Timeout           *durationpb.Duration `protobuf:"bytes,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Debug             bool                 `protobuf:"varint,12,opt,name=debug,proto3" json:"debug,omitempty"` // 是否开启调试模式
+}
+
+func (x *Mongo) Reset() {
+	*x = Mongo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mongo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mongo) ProtoMessage() {}
+
+func (x *Mongo) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mongo.ProtoReflect.Descriptor instead.
+func (*Mongo) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Mongo) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Mongo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Mongo) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *Mongo) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *Mongo) GetMaxPoolSize() uint64 {
+	if x != nil {
+		return x.MaxPoolSize
+	}
+	return 0
+}
+
+func (x *Mongo) GetMinPoolSize() uint64 {
+	if x != nil {
+		return x.MinPoolSize
+	}
+	return 0
+}
+
+func (x *Mongo) GetMaxConnection() uint64 {
+	if x != nil {
+		return x.MaxConnection
+	}
+	return 0
+}
+
+func (x *Mongo) GetConnectTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ConnectTimeout
+	}
+	return nil
+}
+
+func (x *Mongo) GetHeartbeatInterval() *durationpb.Duration {
+	if x != nil {
+		return x.HeartbeatInterval
+	}
+	return nil
+}
+
+func (x *Mongo) GetMaxConnIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxConnIdleTime
+	}
+	return nil
+}
+
+func (x *Mongo) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Mongo) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+type Database struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable          bool                 `protobuf:"varint,9,opt,name=enable,proto3" json:"enable,omitempty"`
+	Driver          string               `protobuf:"bytes,10,opt,name=driver,proto3" json:"driver,omitempty"` // 数据库的驱动，用于支持不同的数据库引擎, 如 mysql/sqlite/pgx
+	Addr            string               `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`      // 数据库链接地址, 包含了地址/用户名/密码及数据库
+	Database        string               `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	MaxPoolIdleSize uint64               `protobuf:"varint,4,opt,name=max_pool_idle_size,json=maxPoolIdleSize,proto3" json:"max_pool_idle_size,omitempty"` // 最大空闲连接
+	MaxConnection   uint64               `protobuf:"varint,6,opt,name=max_connection,json=maxConnection,proto3" json:"max_connection,omitempty"`           // 最大连接数
+	ConnMaxLifetime *durationpb.Duration `protobuf:"bytes,7,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
+	ConnMaxIdleTime *durationpb.Duration `protobuf:"bytes,8,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"`
+}
+
+func (x *Database) Reset() {
+	*x = Database{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Database) ProtoMessage() {}
+
+func (x *Database) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Database.ProtoReflect.Descriptor instead.
+func (*Database) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Database) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Database) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Database) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Database) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *Database) GetMaxPoolIdleSize() uint64 {
+	if x != nil {
+		return x.MaxPoolIdleSize
+	}
+	return 0
+}
+
+func (x *Database) GetMaxConnection() uint64 {
+	if x != nil {
+		return x.MaxConnection
+	}
+	return 0
+}
+
+func (x *Database) GetConnMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxLifetime
+	}
+	return nil
+}
+
+func (x *Database) GetConnMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxIdleTime
+	}
+	return nil
+}
+
+// DatabaseInstances 多实例数据库配置, key 为实例名称, 用于同一服务需要访问多个数据库的场景
+type DatabaseInstances struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instances map[string]*Database `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DatabaseInstances) Reset() {
+	*x = DatabaseInstances{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatabaseInstances) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseInstances) ProtoMessage() {}
+
+func (x *DatabaseInstances) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseInstances.ProtoReflect.Descriptor instead.
+func (*DatabaseInstances) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DatabaseInstances) GetInstances() map[string]*Database {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+// RedisInstances 多实例 Redis 配置, key 为实例名称, 如缓存与队列使用不同的 Redis 集群
+type RedisInstances struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instances map[string]*Redis `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *RedisInstances) Reset() {
+	*x = RedisInstances{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedisInstances) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedisInstances) ProtoMessage() {}
+
+func (x *RedisInstances) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedisInstances.ProtoReflect.Descriptor instead.
+func (*RedisInstances) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RedisInstances) GetInstances() map[string]*Redis {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+// MongoInstances 多实例 MongoDB 配置, key 为实例名称
+type MongoInstances struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instances map[string]*Mongo `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *MongoInstances) Reset() {
+	*x = MongoInstances{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MongoInstances) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MongoInstances) ProtoMessage() {}
+
+func (x *MongoInstances) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MongoInstances.ProtoReflect.Descriptor instead.
+func (*MongoInstances) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MongoInstances) GetInstances() map[string]*Mongo {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type Profile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EnableCpu bool   `protobuf:"varint,1,opt,name=enable_cpu,json=enableCpu,proto3" json:"enable_cpu,omitempty"` // 是否启用 CPU Profile
+	EnableMem bool   `protobuf:"varint,2,opt,name=enable_mem,json=enableMem,proto3" json:"enable_mem,omitempty"` // 是否启用 Mem Profile
+	CpuFile   string `protobuf:"bytes,3,opt,name=cpu_file,json=cpuFile,proto3" json:"cpu_file,omitempty"`        // CPU Profile 文件的保存地址
+	MemFile   string `protobuf:"bytes,4,opt,name=mem_file,json=memFile,proto3" json:"mem_file,omitempty"`        // MEM Profile 文件的保存地址
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Profile) GetEnableCpu() bool {
+	if x != nil {
+		return x.EnableCpu
+	}
+	return false
+}
+
+func (x *Profile) GetEnableMem() bool {
+	if x != nil {
+		return x.EnableMem
+	}
+	return false
+}
+
+func (x *Profile) GetCpuFile() string {
+	if x != nil {
+		return x.CpuFile
+	}
+	return ""
+}
+
+func (x *Profile) GetMemFile() string {
+	if x != nil {
+		return x.MemFile
+	}
+	return ""
+}
+
+// Metrics 监控指标配置, 服务端及客户端的调用指标以及 Go 运行时、进程指标使用 Prometheus 格式通过 admin 管理端点或独立的地址暴露
+type Metrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable  bool   `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`  // 是否暴露监控指标, 支持动态开关, 关闭时访问 path 返回 404
+	Path    string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`       // 监控指标的 HTTP 路径, 默认为 /metrics, 修改后需要重启
+	Address string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // 监控指标独立的监听地址, 为空时由 admin 管理端点提供, 不会挂载到业务 HTTP 服务上, 修改后需要重启
+}
+
+func (x *Metrics) Reset() {
+	*x = Metrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics) ProtoMessage() {}
+
+func (x *Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Metrics) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *Metrics) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Metrics) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// JWT 鉴权配置, 配置在每次请求时读取, 修改后无需重启即可生效
+type JWT struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enable            bool                 `protobuf:"varint,14,opt,name=enable,proto3" json:"enable,omitempty"`
-	Address           string               `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`                                              // MongoDB 的连接字符串，支持集群的形式
-	Hosts             []string             `protobuf:"bytes,3,rep,name=hosts,proto3" json:"hosts,omitempty"`                                                  // MongoDB 的集群地址列表
-	Database          string               `protobuf:"bytes,4,opt,name=database,proto3" json:"database,omitempty"`                                            // 数据库名称
-	MaxPoolSize       uint64               `protobuf:"varint,5,opt,name=max_pool_size,json=maxPoolSize,proto3" json:"max_pool_size,omitempty"`                // 最大空闲连接
-	MinPoolSize       uint64               `protobuf:"varint,6,opt,name=min_pool_size,json=minPoolSize,proto3" json:"min_pool_size,omitempty"`                // 最小空闲连接
-	MaxConnection     uint64               `protobuf:"varint,7,opt,name=max_connection,json=maxConnection,proto3" json:"max_connection,omitempty"`            // 最大连接数
-	ConnectTimeout    *durationpb.Duration `protobuf:"bytes,8,opt,name=connect_timeout,json=connectTimeout,proto3" json:"connect_timeout,omitempty"`          // 连接超时时间
-	HeartbeatInterval *durationpb.Duration `protobuf:"bytes,9,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"` // 心跳间隔
-	MaxConnIdleTime   *durationpb.Duration `protobuf:"bytes,10,opt,name=max_conn_idle_time,json=maxConnIdleTime,proto3" json:"max_conn_idle_time,omitempty"`
-	Timeout           *durationpb.Duration `protobuf:"bytes,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Debug             bool                 `protobuf:"varint,12,opt,name=debug,proto3" json:"debug,omitempty"` // 是否开启调试模式
+	Enable           bool                 `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                            // 是否启用 JWT 鉴权
+	PublicOperations []string             `protobuf:"bytes,2,rep,name=public_operations,json=publicOperations,proto3" json:"public_operations,omitempty"` // 无需鉴权的接口, 如 /api.user.v1.User/Login, 以 * 结尾时按前缀匹配
+	Audiences        []string             `protobuf:"bytes,3,rep,name=audiences,proto3" json:"audiences,omitempty"`                                       // 允许的 aud, 令牌的 aud 需包含其中之一, 为空时不校验
+	Issuer           string               `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`                                             // 允许的 iss, 为空时不校验
+	Secret           string               `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`                                             // HS256/HS384/HS512 使用的密钥, 为空时使用 /basic/secret 中的 jwt_encrypt.key
+	PublicKey        string               `protobuf:"bytes,6,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`                      // RS*/ES*/EdDSA 使用的 PEM 格式公钥, 配置后不再接受 HMAC 签名的令牌
+	Leeway           *durationpb.Duration `protobuf:"bytes,7,opt,name=leeway,proto3" json:"leeway,omitempty"`                                             // 校验 exp 及 nbf 时允许的时钟偏差, 默认为 0
 }
 
-func (x *Mongo) Reset() {
-	*x = Mongo{}
+func (x *JWT) Reset() {
+	*x = JWT{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_config_def_default_v1_proto_msgTypes[10]
+		mi := &file_config_def_default_v1_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Mongo) String() string {
+func (x *JWT) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Mongo) ProtoMessage() {}
+func (*JWT) ProtoMessage() {}
 
-func (x *Mongo) ProtoReflect() protoreflect.Message {
-	mi := &file_config_def_default_v1_proto_msgTypes[10]
+func (x *JWT) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -954,127 +2126,161 @@ func (x *Mongo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Mongo.ProtoReflect.Descriptor instead.
-func (*Mongo) Descriptor() ([]byte, []int) {
-	return file_config_def_default_v1_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use JWT.ProtoReflect.Descriptor instead.
+func (*JWT) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *Mongo) GetEnable() bool {
+func (x *JWT) GetEnable() bool {
 	if x != nil {
 		return x.Enable
 	}
 	return false
 }
 
-func (x *Mongo) GetAddress() string {
+func (x *JWT) GetPublicOperations() []string {
 	if x != nil {
-		return x.Address
+		return x.PublicOperations
 	}
-	return ""
+	return nil
 }
 
-func (x *Mongo) GetHosts() []string {
+func (x *JWT) GetAudiences() []string {
 	if x != nil {
-		return x.Hosts
+		return x.Audiences
 	}
 	return nil
 }
 
-func (x *Mongo) GetDatabase() string {
+func (x *JWT) GetIssuer() string {
 	if x != nil {
-		return x.Database
+		return x.Issuer
 	}
 	return ""
 }
 
-func (x *Mongo) GetMaxPoolSize() uint64 {
+func (x *JWT) GetSecret() string {
 	if x != nil {
-		return x.MaxPoolSize
+		return x.Secret
 	}
-	return 0
+	return ""
 }
 
-func (x *Mongo) GetMinPoolSize() uint64 {
+func (x *JWT) GetPublicKey() string {
 	if x != nil {
-		return x.MinPoolSize
+		return x.PublicKey
 	}
-	return 0
+	return ""
 }
 
-func (x *Mongo) GetMaxConnection() uint64 {
+func (x *JWT) GetLeeway() *durationpb.Duration {
 	if x != nil {
-		return x.MaxConnection
+		return x.Leeway
 	}
-	return 0
+	return nil
 }
 
-func (x *Mongo) GetConnectTimeout() *durationpb.Duration {
-	if x != nil {
-		return x.ConnectTimeout
+// Signature 服务间请求签名的校验配置, 调用方使用 /basic/secret 中 service_encrypt 的私钥对请求签名, 配置在每次请求时读取
+type Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable      bool                 `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                                                                                                     // 是否校验请求签名
+	TrustedKeys map[string]string    `protobuf:"bytes,2,rep,name=trusted_keys,json=trustedKeys,proto3" json:"trusted_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 受信任的调用方公钥, key 为调用方的服务名 (x-md-service-name), value 为 PEM 格式的公钥
+	Window      *durationpb.Duration `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`                                                                                                                      // 允许的时间偏差, 超出该范围的请求视为重放, 默认为 5m
+	Operations  []string             `protobuf:"bytes,4,rep,name=operations,proto3" json:"operations,omitempty"`                                                                                                              // 需要校验签名的接口, 以 * 结尾时按前缀匹配, 为空时校验所有接口
+}
+
+func (x *Signature) Reset() {
+	*x = Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *Mongo) GetHeartbeatInterval() *durationpb.Duration {
+func (x *Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signature) ProtoMessage() {}
+
+func (x *Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Signature) GetEnable() bool {
 	if x != nil {
-		return x.HeartbeatInterval
+		return x.Enable
 	}
-	return nil
+	return false
 }
 
-func (x *Mongo) GetMaxConnIdleTime() *durationpb.Duration {
+func (x *Signature) GetTrustedKeys() map[string]string {
 	if x != nil {
-		return x.MaxConnIdleTime
+		return x.TrustedKeys
 	}
 	return nil
 }
 
-func (x *Mongo) GetTimeout() *durationpb.Duration {
+func (x *Signature) GetWindow() *durationpb.Duration {
 	if x != nil {
-		return x.Timeout
+		return x.Window
 	}
 	return nil
 }
 
-func (x *Mongo) GetDebug() bool {
+func (x *Signature) GetOperations() []string {
 	if x != nil {
-		return x.Debug
+		return x.Operations
 	}
-	return false
+	return nil
 }
 
-type Database struct {
+// Idempotency 幂等配置, 调用方通过 Idempotency-Key 请求头 (或 x-md-idempotency-key 元数据) 标识重复的请求, 配置在每次请求时读取
+type Idempotency struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enable          bool                 `protobuf:"varint,9,opt,name=enable,proto3" json:"enable,omitempty"`
-	Driver          string               `protobuf:"bytes,10,opt,name=driver,proto3" json:"driver,omitempty"` // 数据库的驱动，用于支持不同的数据库引擎, 如 mysql/sqlite/pgx
-	Addr            string               `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`      // 数据库链接地址, 包含了地址/用户名/密码及数据库
-	Database        string               `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
-	MaxPoolIdleSize uint64               `protobuf:"varint,4,opt,name=max_pool_idle_size,json=maxPoolIdleSize,proto3" json:"max_pool_idle_size,omitempty"` // 最大空闲连接
-	MaxConnection   uint64               `protobuf:"varint,6,opt,name=max_connection,json=maxConnection,proto3" json:"max_connection,omitempty"`           // 最大连接数
-	ConnMaxLifetime *durationpb.Duration `protobuf:"bytes,7,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
-	ConnMaxIdleTime *durationpb.Duration `protobuf:"bytes,8,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"`
+	Enable      bool                 `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                             // 是否启用幂等处理, 需要注入 Redis
+	Operations  []string             `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`                      // 启用幂等处理的接口, 以 * 结尾时按前缀匹配
+	Ttl         *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`                                    // 处理结果的保存时间, 期间相同 key 的请求直接返回保存的结果, 默认为 24h
+	LockTimeout *durationpb.Duration `protobuf:"bytes,4,opt,name=lock_timeout,json=lockTimeout,proto3" json:"lock_timeout,omitempty"` // 处理中的锁的过期时间, 应大于接口的最长处理时间, 默认为 1m
 }
 
-func (x *Database) Reset() {
-	*x = Database{}
+func (x *Idempotency) Reset() {
+	*x = Idempotency{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_config_def_default_v1_proto_msgTypes[11]
+		mi := &file_config_def_default_v1_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Database) String() string {
+func (x *Idempotency) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Database) ProtoMessage() {}
+func (*Idempotency) ProtoMessage() {}
 
-func (x *Database) ProtoReflect() protoreflect.Message {
-	mi := &file_config_def_default_v1_proto_msgTypes[11]
+func (x *Idempotency) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1085,95 +2291,133 @@ func (x *Database) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Database.ProtoReflect.Descriptor instead.
-func (*Database) Descriptor() ([]byte, []int) {
-	return file_config_def_default_v1_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use Idempotency.ProtoReflect.Descriptor instead.
+func (*Idempotency) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *Database) GetEnable() bool {
+func (x *Idempotency) GetEnable() bool {
 	if x != nil {
 		return x.Enable
 	}
 	return false
 }
 
-func (x *Database) GetDriver() string {
+func (x *Idempotency) GetOperations() []string {
 	if x != nil {
-		return x.Driver
+		return x.Operations
 	}
-	return ""
+	return nil
 }
 
-func (x *Database) GetAddr() string {
+func (x *Idempotency) GetTtl() *durationpb.Duration {
 	if x != nil {
-		return x.Addr
+		return x.Ttl
 	}
-	return ""
+	return nil
 }
 
-func (x *Database) GetDatabase() string {
+func (x *Idempotency) GetLockTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Database
+		return x.LockTimeout
 	}
-	return ""
+	return nil
 }
 
-func (x *Database) GetMaxPoolIdleSize() uint64 {
-	if x != nil {
-		return x.MaxPoolIdleSize
+// RateLimit 限流配置, 规则在每次请求时读取，修改后无需重启即可生效
+type RateLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable         bool             `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                      // 是否启用限流
+	Rules          []*RateLimitRule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`                                         // 限流规则，按顺序匹配，一个请求会受所有匹配规则的限制
+	TrustedProxies []string         `protobuf:"bytes,3,rep,name=trusted_proxies,json=trustedProxies,proto3" json:"trusted_proxies,omitempty"` // 受信任的代理 IP 或 CIDR, 只有请求来自这些代理时才使用 X-Forwarded-For 及 X-Real-IP 获取客户端 IP
+}
+
+func (x *RateLimit) Reset() {
+	*x = RateLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Database) GetMaxConnection() uint64 {
+func (x *RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimit) ProtoMessage() {}
+
+func (x *RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimit.ProtoReflect.Descriptor instead.
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RateLimit) GetEnable() bool {
 	if x != nil {
-		return x.MaxConnection
+		return x.Enable
 	}
-	return 0
+	return false
 }
 
-func (x *Database) GetConnMaxLifetime() *durationpb.Duration {
+func (x *RateLimit) GetRules() []*RateLimitRule {
 	if x != nil {
-		return x.ConnMaxLifetime
+		return x.Rules
 	}
 	return nil
 }
 
-func (x *Database) GetConnMaxIdleTime() *durationpb.Duration {
+func (x *RateLimit) GetTrustedProxies() []string {
 	if x != nil {
-		return x.ConnMaxIdleTime
+		return x.TrustedProxies
 	}
 	return nil
 }
 
-type Profile struct {
+type RateLimitRule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EnableCpu bool   `protobuf:"varint,1,opt,name=enable_cpu,json=enableCpu,proto3" json:"enable_cpu,omitempty"` // 是否启用 CPU Profile
-	EnableMem bool   `protobuf:"varint,2,opt,name=enable_mem,json=enableMem,proto3" json:"enable_mem,omitempty"` // 是否启用 Mem Profile
-	CpuFile   string `protobuf:"bytes,3,opt,name=cpu_file,json=cpuFile,proto3" json:"cpu_file,omitempty"`        // CPU Profile 文件的保存地址
-	MemFile   string `protobuf:"bytes,4,opt,name=mem_file,json=memFile,proto3" json:"mem_file,omitempty"`        // MEM Profile 文件的保存地址
+	Operation string               `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"` // 匹配的 Operation, 如 /api.user.v1.User/Export, 以 * 结尾时按前缀匹配, 为空或 * 时匹配所有接口
+	Key       string               `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`             // 限流维度, ip: 客户端 IP, token: 用户 Token, header:<name>: 指定的请求头, 为空时对接口整体限流
+	Rate      float64              `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`         // 每秒允许的请求数
+	Burst     int32                `protobuf:"varint,4,opt,name=burst,proto3" json:"burst,omitempty"`        // 本地令牌桶的容量，默认为 rate 向上取整
+	Backend   string               `protobuf:"bytes,5,opt,name=backend,proto3" json:"backend,omitempty"`     // 限流的实现, local: 本地令牌桶 (默认), redis: 基于 Redis 的分布式滑动窗口
+	Window    *durationpb.Duration `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`       // redis 滑动窗口的大小, 默认为 1s, 窗口内允许 rate * window 个请求
 }
 
-func (x *Profile) Reset() {
-	*x = Profile{}
+func (x *RateLimitRule) Reset() {
+	*x = RateLimitRule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_config_def_default_v1_proto_msgTypes[12]
+		mi := &file_config_def_default_v1_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Profile) String() string {
+func (x *RateLimitRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Profile) ProtoMessage() {}
+func (*RateLimitRule) ProtoMessage() {}
 
-func (x *Profile) ProtoReflect() protoreflect.Message {
-	mi := &file_config_def_default_v1_proto_msgTypes[12]
+func (x *RateLimitRule) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,39 +2428,53 @@ func (x *Profile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
-func (*Profile) Descriptor() ([]byte, []int) {
-	return file_config_def_default_v1_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use RateLimitRule.ProtoReflect.Descriptor instead.
+func (*RateLimitRule) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *Profile) GetEnableCpu() bool {
+func (x *RateLimitRule) GetOperation() string {
 	if x != nil {
-		return x.EnableCpu
+		return x.Operation
 	}
-	return false
+	return ""
 }
 
-func (x *Profile) GetEnableMem() bool {
+func (x *RateLimitRule) GetKey() string {
 	if x != nil {
-		return x.EnableMem
+		return x.Key
 	}
-	return false
+	return ""
 }
 
-func (x *Profile) GetCpuFile() string {
+func (x *RateLimitRule) GetRate() float64 {
 	if x != nil {
-		return x.CpuFile
+		return x.Rate
 	}
-	return ""
+	return 0
 }
 
-func (x *Profile) GetMemFile() string {
+func (x *RateLimitRule) GetBurst() int32 {
 	if x != nil {
-		return x.MemFile
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *RateLimitRule) GetBackend() string {
+	if x != nil {
+		return x.Backend
 	}
 	return ""
 }
 
+func (x *RateLimitRule) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
 // Console 输出到控制台
 type Log_Console struct {
 	state         protoimpl.MessageState
@@ -1232,7 +2490,7 @@ type Log_Console struct {
 func (x *Log_Console) Reset() {
 	*x = Log_Console{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_config_def_default_v1_proto_msgTypes[13]
+		mi := &file_config_def_default_v1_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1245,7 +2503,7 @@ func (x *Log_Console) String() string {
 func (*Log_Console) ProtoMessage() {}
 
 func (x *Log_Console) ProtoReflect() protoreflect.Message {
-	mi := &file_config_def_default_v1_proto_msgTypes[13]
+	mi := &file_config_def_default_v1_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1258,7 +2516,7 @@ func (x *Log_Console) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Console.ProtoReflect.Descriptor instead.
 func (*Log_Console) Descriptor() ([]byte, []int) {
-	return file_config_def_default_v1_proto_rawDescGZIP(), []int{8, 0}
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{15, 0}
 }
 
 func (x *Log_Console) GetEnable() bool {
@@ -1299,7 +2557,7 @@ type Log_Graylog struct {
 func (x *Log_Graylog) Reset() {
 	*x = Log_Graylog{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_config_def_default_v1_proto_msgTypes[14]
+		mi := &file_config_def_default_v1_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1312,7 +2570,7 @@ func (x *Log_Graylog) String() string {
 func (*Log_Graylog) ProtoMessage() {}
 
 func (x *Log_Graylog) ProtoReflect() protoreflect.Message {
-	mi := &file_config_def_default_v1_proto_msgTypes[14]
+	mi := &file_config_def_default_v1_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1325,7 +2583,7 @@ func (x *Log_Graylog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Graylog.ProtoReflect.Descriptor instead.
 func (*Log_Graylog) Descriptor() ([]byte, []int) {
-	return file_config_def_default_v1_proto_rawDescGZIP(), []int{8, 1}
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{15, 1}
 }
 
 func (x *Log_Graylog) GetEnable() bool {
@@ -1400,7 +2658,7 @@ type Log_File struct {
 func (x *Log_File) Reset() {
 	*x = Log_File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_config_def_default_v1_proto_msgTypes[15]
+		mi := &file_config_def_default_v1_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1413,7 +2671,7 @@ func (x *Log_File) String() string {
 func (*Log_File) ProtoMessage() {}
 
 func (x *Log_File) ProtoReflect() protoreflect.Message {
-	mi := &file_config_def_default_v1_proto_msgTypes[15]
+	mi := &file_config_def_default_v1_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1426,7 +2684,7 @@ func (x *Log_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_File.ProtoReflect.Descriptor instead.
 func (*Log_File) Descriptor() ([]byte, []int) {
-	return file_config_def_default_v1_proto_rawDescGZIP(), []int{8, 2}
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{15, 2}
 }
 
 func (x *Log_File) GetEnable() bool {
@@ -1443,46 +2701,118 @@ func (x *Log_File) GetLevel() string {
 	return ""
 }
 
-func (x *Log_File) GetDir() string {
-	if x != nil {
-		return x.Dir
+func (x *Log_File) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *Log_File) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Log_File) GetRotateTime() *durationpb.Duration {
+	if x != nil {
+		return x.RotateTime
+	}
+	return nil
+}
+
+func (x *Log_File) GetRotateSize() int64 {
+	if x != nil {
+		return x.RotateSize
+	}
+	return 0
+}
+
+func (x *Log_File) GetStorageAge() *durationpb.Duration {
+	if x != nil {
+		return x.StorageAge
+	}
+	return nil
+}
+
+func (x *Log_File) GetStorageCounter() uint32 {
+	if x != nil {
+		return x.StorageCounter
+	}
+	return 0
+}
+
+// Payload 请求及响应内容的日志配置, 适用于 HTTP/GRPC 服务端、客户端及消息队列, 配置在每次记录时读取
+type Log_Payload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable       bool     `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`                                // 是否记录请求及响应内容
+	MaxSize      int32    `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`               // 单个内容记录的最大字节数, 超出部分被截断, 默认为 4096
+	SampleRate   float64  `protobuf:"fixed64,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`     // 采样比例, 取值为 (0, 1], 默认为 1
+	RedactFields []string `protobuf:"bytes,4,rep,name=redact_fields,json=redactFields,proto3" json:"redact_fields,omitempty"` // 需要脱敏的字段名, 忽略大小写、下划线及中划线, 支持 * 通配, 如 password、*token、id_card,
+}
+
+func (x *Log_Payload) Reset() {
+	*x = Log_Payload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_def_default_v1_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Log_Payload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_Payload) ProtoMessage() {}
+
+func (x *Log_Payload) ProtoReflect() protoreflect.Message {
+	mi := &file_config_def_default_v1_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Log_File) GetFilename() string {
-	if x != nil {
-		return x.Filename
-	}
-	return ""
+// Deprecated: Use Log_Payload.ProtoReflect.Descriptor instead.
+func (*Log_Payload) Descriptor() ([]byte, []int) {
+	return file_config_def_default_v1_proto_rawDescGZIP(), []int{15, 3}
 }
 
-func (x *Log_File) GetRotateTime() *durationpb.Duration {
+func (x *Log_Payload) GetEnable() bool {
 	if x != nil {
-		return x.RotateTime
+		return x.Enable
 	}
-	return nil
+	return false
 }
 
-func (x *Log_File) GetRotateSize() int64 {
+func (x *Log_Payload) GetMaxSize() int32 {
 	if x != nil {
-		return x.RotateSize
+		return x.MaxSize
 	}
 	return 0
 }
 
-func (x *Log_File) GetStorageAge() *durationpb.Duration {
+func (x *Log_Payload) GetSampleRate() float64 {
 	if x != nil {
-		return x.StorageAge
+		return x.SampleRate
 	}
-	return nil
+	return 0
 }
 
-func (x *Log_File) GetStorageCounter() uint32 {
+func (x *Log_Payload) GetRedactFields() []string {
 	if x != nil {
-		return x.StorageCounter
+		return x.RedactFields
 	}
-	return 0
+	return nil
 }
 
 var File_config_def_default_v1_proto protoreflect.FileDescriptor
@@ -1493,39 +2823,164 @@ var file_config_def_default_v1_proto_rawDesc = []byte{
 	0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x70, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x32, 0x0a,
-	0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x69,
-	0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x52, 0x04, 0x68, 0x74, 0x74,
-	0x70, 0x12, 0x32, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1e, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x52,
-	0x04, 0x67, 0x72, 0x70, 0x63, 0x22, 0xd9, 0x01, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e,
-	0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64,
-	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x72, 0x6c, 0x5f, 0x70, 0x61, 0x74,
-	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x72, 0x6c, 0x50, 0x61, 0x74, 0x68,
-	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d,
-	0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d,
-	0x61, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x69, 0x7a,
-	0x65, 0x22, 0xb3, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x12, 0x16,
-	0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x6f, 0x74, 0x6f, 0x22, 0xe7, 0x02, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x32,
+	0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b,
+	0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x52, 0x04, 0x68, 0x74,
+	0x74, 0x70, 0x12, 0x32, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79,
+	0x52, 0x04, 0x67, 0x72, 0x70, 0x63, 0x12, 0x3a, 0x0a, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f,
+	0x77, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e,
+	0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f,
+	0x77, 0x6e, 0x12, 0x47, 0x0a, 0x0d, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x68, 0x65, 0x64, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x69, 0x74, 0x2e,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31,
+	0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x68, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0c, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x68, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x3d, 0x0a, 0x09, 0x64,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52,
+	0x09, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6b, 0x69, 0x74, 0x2e,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x22, 0x39, 0x0a,
+	0x05, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x03,
+	0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x72, 0x22, 0xb3, 0x01, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x68,
+	0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x31,
+	0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x70, 0x75,
+	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x63, 0x70, 0x75, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x22, 0xa2, 0x01, 0x0a, 0x08,
+	0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x3a, 0x0a, 0x0b, 0x64, 0x72, 0x61, 0x69,
+	0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xe8, 0x01, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x61, 0x74, 0x68,
+	0x22, 0xd9, 0x01, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x75, 0x72, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x75, 0x72, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xa9, 0x04, 0x0a,
+	0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x64, 0x0a, 0x12, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35,
+	0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x4d, 0x0a, 0x0f, 0x63, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74,
+	0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x52, 0x0e, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74,
+	0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x6c,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x54,
+	0x4c, 0x53, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x1a, 0x5f, 0x0a, 0x16, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8a, 0x02, 0x0a, 0x03, 0x54, 0x4c, 0x53,
+	0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x65, 0x72,
+	0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x65, 0x72,
+	0x74, 0x5f, 0x70, 0x65, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x65, 0x72,
+	0x74, 0x50, 0x65, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x65, 0x6d, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x50, 0x65, 0x6d, 0x12, 0x15, 0x0a,
+	0x06, 0x63, 0x61, 0x5f, 0x70, 0x65, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63,
+	0x61, 0x50, 0x65, 0x6d, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x43, 0x65, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x97, 0x02, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x3a,
+	0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x75,
+	0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x15, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x95, 0x02, 0x0a, 0x0e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6f, 0x70, 0x65,
+	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6f, 0x70, 0x65, 0x6e,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xe8, 0x01, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61,
 	0x12, 0x3a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
 	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
@@ -1581,7 +3036,7 @@ var file_config_def_default_v1_proto_rawDesc = []byte{
 	0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x71,
 	0x6f, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
 	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x71, 0x6f, 0x73, 0x50, 0x72, 0x65, 0x66,
-	0x65, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xc5, 0x06, 0x0a, 0x03, 0x4c, 0x6f,
+	0x65, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xa2, 0x08, 0x0a, 0x03, 0x4c, 0x6f,
 	0x67, 0x12, 0x3b, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
 	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x43, 0x6f,
@@ -1592,143 +3047,262 @@ var file_config_def_default_v1_proto_rawDesc = []byte{
 	0x6f, 0x67, 0x52, 0x07, 0x67, 0x72, 0x61, 0x79, 0x6c, 0x6f, 0x67, 0x12, 0x32, 0x0a, 0x04, 0x66,
 	0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x69, 0x74, 0x2e,
 	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31,
-	0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x1a,
-	0x61, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x5f,
-	0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x45, 0x6e, 0x63, 0x6f, 0x64,
-	0x65, 0x72, 0x1a, 0xa5, 0x01, 0x0a, 0x07, 0x47, 0x72, 0x61, 0x79, 0x6c, 0x6f, 0x67, 0x12, 0x16,
-	0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69,
-	0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69,
-	0x74, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x70, 0x6f, 0x6f, 0x6c,
-	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x61, 0x73, 0x79,
-	0x6e, 0x63, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x1a, 0xa4, 0x02, 0x0a, 0x04, 0x46,
-	0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65,
-	0x6c, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x64, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x3a, 0x0a, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0a, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
-	0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0a, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x3a, 0x0a, 0x0b,
-	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x41, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65,
-	0x72, 0x22, 0x5e, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x45, 0x6e, 0x75,
-	0x6d, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
-	0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10,
-	0x02, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x45,
-	0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x41, 0x54, 0x41, 0x4c, 0x10,
-	0x05, 0x22, 0xe0, 0x03, 0x0a, 0x05, 0x52, 0x65, 0x64, 0x69, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x6f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
-	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x64,
-	0x62, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x61,
-	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3e, 0x0a, 0x0d, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x64, 0x69, 0x61, 0x6c, 0x5f,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x6c, 0x54, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x6f,
-	0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x61,
-	0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x61, 0x78,
-	0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x64,
-	0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x6f,
-	0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x64, 0x6c, 0x65, 0x53,
-	0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x22, 0xfb, 0x03, 0x0a, 0x05, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x12, 0x16,
-	0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x6f,
-	0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x6f,
-	0x6f, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d,
-	0x69, 0x6e, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61,
-	0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x42, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x48, 0x0a, 0x12, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
-	0x61, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x68, 0x65,
-	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
-	0x46, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x6c, 0x65,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x66, 0x78, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x66, 0x78, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x3b, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x69,
+	0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x61, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x73, 0x6f,
+	0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x4a,
+	0x73, 0x6f, 0x6e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x72, 0x1a, 0xa5, 0x01, 0x0a, 0x07, 0x47,
+	0x72, 0x61, 0x79, 0x6c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64,
+	0x64, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x66, 0x61, 0x63, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x73,
+	0x79, 0x6e, 0x63, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x69,
+	0x7a, 0x65, 0x1a, 0xa4, 0x02, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74,
+	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f,
+	0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x41, 0x67, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x1a, 0x82, 0x01, 0x0a, 0x07, 0x50, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x64,
+	0x61, 0x63, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0c, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x5e,
+	0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x19,
+	0x0a, 0x15, 0x4c, 0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42,
+	0x55, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x02, 0x12, 0x08,
+	0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x41, 0x54, 0x41, 0x4c, 0x10, 0x05, 0x22, 0xe0,
+	0x03, 0x0a, 0x05, 0x52, 0x65, 0x64, 0x69, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x6f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x0e,
+	0x0a, 0x02, 0x64, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x64, 0x62, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x3e, 0x0a, 0x0d, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x49,
-	0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
-	0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x62,
-	0x75, 0x67, 0x22, 0xcd, 0x02, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65,
-	0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12,
-	0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61,
-	0x64, 0x64, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12,
-	0x2b, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x6c, 0x65,
-	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78,
-	0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x64, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e,
-	0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
-	0x6c, 0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x77, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x64, 0x69, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x6f,
+	0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f,
+	0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x64, 0x6c, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f,
+	0x69, 0x64, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0f, 0x6d, 0x69, 0x6e, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x64, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0xfb, 0x03, 0x0a, 0x05, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x50,
+	0x6f, 0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0d, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x42,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x48, 0x0a, 0x12, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x4d,
-	0x61, 0x78, 0x4c, 0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x46, 0x0a, 0x12, 0x63, 0x6f,
-	0x6e, 0x6e, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x4d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x54, 0x69,
-	0x6d, 0x65, 0x22, 0x7d, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x09, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x70, 0x75, 0x12, 0x1d, 0x0a, 0x0a,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x09, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x63,
-	0x70, 0x75, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
-	0x70, 0x75, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x5f, 0x66, 0x69,
-	0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x46, 0x69, 0x6c,
-	0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x65, 0x64, 0x65, 0x6e, 0x2d, 0x71, 0x75, 0x61, 0x6e, 0x2f, 0x67, 0x6f, 0x2d, 0x62, 0x69, 0x7a,
-	0x2d, 0x6b, 0x69, 0x74, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x64, 0x65, 0x66, 0x3b,
-	0x64, 0x65, 0x66, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x68, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x46, 0x0a, 0x12,
+	0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x6c, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x22,
+	0xcd, 0x02, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x12,
+	0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x6f,
+	0x6c, 0x49, 0x64, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x78,
+	0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x45, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x69, 0x66,
+	0x65, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x4d, 0x61, 0x78, 0x4c,
+	0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x46, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x6e, 0x5f,
+	0x6d, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f,
+	0x63, 0x6f, 0x6e, 0x6e, 0x4d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0xc7, 0x01, 0x0a, 0x11, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x54, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x1a, 0x5c, 0x0a, 0x0e, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbe, 0x01, 0x0a, 0x0e, 0x52, 0x65,
+	0x64, 0x69, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x51, 0x0a, 0x09,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x33, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x73, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x1a,
+	0x59, 0x0a, 0x0e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbe, 0x01, 0x0a, 0x0e, 0x4d,
+	0x6f, 0x6e, 0x67, 0x6f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x51, 0x0a,
+	0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x33, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x1a, 0x59, 0x0a, 0x0e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x6e, 0x67, 0x6f,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7d, 0x0a, 0x07, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x43, 0x70, 0x75, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x6d, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x4d, 0x65, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x70, 0x75, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x70, 0x75, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x4f, 0x0a, 0x07, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xea, 0x01, 0x0a, 0x03,
+	0x4a, 0x57, 0x54, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x64,
+	0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x6c, 0x65, 0x65, 0x77, 0x61, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x6c, 0x65, 0x65, 0x77, 0x61, 0x79, 0x22, 0x8b, 0x02, 0x0a, 0x09, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x53,
+	0x0a, 0x0c, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x79,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65,
+	0x64, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb0, 0x01, 0x0a, 0x0b, 0x49, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b,
+	0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x3c, 0x0a, 0x0c, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6c, 0x6f,
+	0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x09, 0x52, 0x61,
+	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x39, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x6b, 0x69, 0x74, 0x2e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52,
+	0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72,
+	0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x78,
+	0x69, 0x65, 0x73, 0x22, 0xb6, 0x01, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72,
+	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x42, 0x30, 0x5a, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x64, 0x65, 0x6e, 0x2d,
+	0x71, 0x75, 0x61, 0x6e, 0x2f, 0x67, 0x6f, 0x2d, 0x62, 0x69, 0x7a, 0x2d, 0x6b, 0x69, 0x74, 0x2f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x64, 0x65, 0x66, 0x3b, 0x64, 0x65, 0x66, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1744,55 +3318,108 @@ func file_config_def_default_v1_proto_rawDescGZIP() []byte {
 }
 
 var file_config_def_default_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_config_def_default_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_config_def_default_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_config_def_default_v1_proto_goTypes = []interface{}{
 	(Log_LogLevelEnum)(0),       // 0: kit.default.configv1.Log.LogLevelEnum
 	(*Server)(nil),              // 1: kit.default.configv1.Server
-	(*Tracing)(nil),             // 2: kit.default.configv1.Tracing
-	(*Registry)(nil),            // 3: kit.default.configv1.Registry
-	(*Data)(nil),                // 4: kit.default.configv1.Data
-	(*RabbitMQ)(nil),            // 5: kit.default.configv1.RabbitMQ
-	(*ExchangeConfig)(nil),      // 6: kit.default.configv1.ExchangeConfig
-	(*QueueConfig)(nil),         // 7: kit.default.configv1.QueueConfig
-	(*ConsumeConfig)(nil),       // 8: kit.default.configv1.ConsumeConfig
-	(*Log)(nil),                 // 9: kit.default.configv1.Log
-	(*Redis)(nil),               // 10: kit.default.configv1.Redis
-	(*Mongo)(nil),               // 11: kit.default.configv1.Mongo
-	(*Database)(nil),            // 12: kit.default.configv1.Database
-	(*Profile)(nil),             // 13: kit.default.configv1.Profile
-	(*Log_Console)(nil),         // 14: kit.default.configv1.Log.Console
-	(*Log_Graylog)(nil),         // 15: kit.default.configv1.Log.Graylog
-	(*Log_File)(nil),            // 16: kit.default.configv1.Log.File
-	(*durationpb.Duration)(nil), // 17: google.protobuf.Duration
+	(*Admin)(nil),               // 2: kit.default.configv1.Admin
+	(*Discovery)(nil),           // 3: kit.default.configv1.Discovery
+	(*LoadShedding)(nil),        // 4: kit.default.configv1.LoadShedding
+	(*Shutdown)(nil),            // 5: kit.default.configv1.Shutdown
+	(*Tracing)(nil),             // 6: kit.default.configv1.Tracing
+	(*Registry)(nil),            // 7: kit.default.configv1.Registry
+	(*TLS)(nil),                 // 8: kit.default.configv1.TLS
+	(*Retry)(nil),               // 9: kit.default.configv1.Retry
+	(*CircuitBreaker)(nil),      // 10: kit.default.configv1.CircuitBreaker
+	(*Data)(nil),                // 11: kit.default.configv1.Data
+	(*RabbitMQ)(nil),            // 12: kit.default.configv1.RabbitMQ
+	(*ExchangeConfig)(nil),      // 13: kit.default.configv1.ExchangeConfig
+	(*QueueConfig)(nil),         // 14: kit.default.configv1.QueueConfig
+	(*ConsumeConfig)(nil),       // 15: kit.default.configv1.ConsumeConfig
+	(*Log)(nil),                 // 16: kit.default.configv1.Log
+	(*Redis)(nil),               // 17: kit.default.configv1.Redis
+	(*Mongo)(nil),               // 18: kit.default.configv1.Mongo
+	(*Database)(nil),            // 19: kit.default.configv1.Database
+	(*DatabaseInstances)(nil),   // 20: kit.default.configv1.DatabaseInstances
+	(*RedisInstances)(nil),      // 21: kit.default.configv1.RedisInstances
+	(*MongoInstances)(nil),      // 22: kit.default.configv1.MongoInstances
+	(*Profile)(nil),             // 23: kit.default.configv1.Profile
+	(*Metrics)(nil),             // 24: kit.default.configv1.Metrics
+	(*JWT)(nil),                 // 25: kit.default.configv1.JWT
+	(*Signature)(nil),           // 26: kit.default.configv1.Signature
+	(*Idempotency)(nil),         // 27: kit.default.configv1.Idempotency
+	(*RateLimit)(nil),           // 28: kit.default.configv1.RateLimit
+	(*RateLimitRule)(nil),       // 29: kit.default.configv1.RateLimitRule
+	nil,                         // 30: kit.default.configv1.Registry.OperationTimeoutsEntry
+	(*Log_Console)(nil),         // 31: kit.default.configv1.Log.Console
+	(*Log_Graylog)(nil),         // 32: kit.default.configv1.Log.Graylog
+	(*Log_File)(nil),            // 33: kit.default.configv1.Log.File
+	(*Log_Payload)(nil),         // 34: kit.default.configv1.Log.Payload
+	nil,                         // 35: kit.default.configv1.DatabaseInstances.InstancesEntry
+	nil,                         // 36: kit.default.configv1.RedisInstances.InstancesEntry
+	nil,                         // 37: kit.default.configv1.MongoInstances.InstancesEntry
+	nil,                         // 38: kit.default.configv1.Signature.TrustedKeysEntry
+	(*durationpb.Duration)(nil), // 39: google.protobuf.Duration
 }
 var file_config_def_default_v1_proto_depIdxs = []int32{
-	3,  // 0: kit.default.configv1.Server.http:type_name -> kit.default.configv1.Registry
-	3,  // 1: kit.default.configv1.Server.grpc:type_name -> kit.default.configv1.Registry
-	17, // 2: kit.default.configv1.Registry.timeout:type_name -> google.protobuf.Duration
-	12, // 3: kit.default.configv1.Data.database:type_name -> kit.default.configv1.Database
-	10, // 4: kit.default.configv1.Data.redis:type_name -> kit.default.configv1.Redis
-	11, // 5: kit.default.configv1.Data.mongodb:type_name -> kit.default.configv1.Mongo
-	5,  // 6: kit.default.configv1.Data.rabbitMq:type_name -> kit.default.configv1.RabbitMQ
-	17, // 7: kit.default.configv1.RabbitMQ.heartbeat:type_name -> google.protobuf.Duration
-	14, // 8: kit.default.configv1.Log.console:type_name -> kit.default.configv1.Log.Console
-	15, // 9: kit.default.configv1.Log.graylog:type_name -> kit.default.configv1.Log.Graylog
-	16, // 10: kit.default.configv1.Log.file:type_name -> kit.default.configv1.Log.File
-	17, // 11: kit.default.configv1.Redis.read_timeout:type_name -> google.protobuf.Duration
-	17, // 12: kit.default.configv1.Redis.write_timeout:type_name -> google.protobuf.Duration
-	17, // 13: kit.default.configv1.Redis.dial_timeout:type_name -> google.protobuf.Duration
-	17, // 14: kit.default.configv1.Mongo.connect_timeout:type_name -> google.protobuf.Duration
-	17, // 15: kit.default.configv1.Mongo.heartbeat_interval:type_name -> google.protobuf.Duration
-	17, // 16: kit.default.configv1.Mongo.max_conn_idle_time:type_name -> google.protobuf.Duration
-	17, // 17: kit.default.configv1.Mongo.timeout:type_name -> google.protobuf.Duration
-	17, // 18: kit.default.configv1.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
-	17, // 19: kit.default.configv1.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
-	17, // 20: kit.default.configv1.Log.File.rotate_time:type_name -> google.protobuf.Duration
-	17, // 21: kit.default.configv1.Log.File.storage_age:type_name -> google.protobuf.Duration
-	22, // [22:22] is the sub-list for method output_type
-	22, // [22:22] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	7,  // 0: kit.default.configv1.Server.http:type_name -> kit.default.configv1.Registry
+	7,  // 1: kit.default.configv1.Server.grpc:type_name -> kit.default.configv1.Registry
+	5,  // 2: kit.default.configv1.Server.shutdown:type_name -> kit.default.configv1.Shutdown
+	4,  // 3: kit.default.configv1.Server.load_shedding:type_name -> kit.default.configv1.LoadShedding
+	3,  // 4: kit.default.configv1.Server.discovery:type_name -> kit.default.configv1.Discovery
+	2,  // 5: kit.default.configv1.Server.admin:type_name -> kit.default.configv1.Admin
+	39, // 6: kit.default.configv1.Discovery.ttl:type_name -> google.protobuf.Duration
+	39, // 7: kit.default.configv1.LoadShedding.window:type_name -> google.protobuf.Duration
+	39, // 8: kit.default.configv1.Shutdown.drain_delay:type_name -> google.protobuf.Duration
+	39, // 9: kit.default.configv1.Shutdown.timeout:type_name -> google.protobuf.Duration
+	39, // 10: kit.default.configv1.Registry.timeout:type_name -> google.protobuf.Duration
+	30, // 11: kit.default.configv1.Registry.operation_timeouts:type_name -> kit.default.configv1.Registry.OperationTimeoutsEntry
+	10, // 12: kit.default.configv1.Registry.circuit_breaker:type_name -> kit.default.configv1.CircuitBreaker
+	9,  // 13: kit.default.configv1.Registry.retry:type_name -> kit.default.configv1.Retry
+	8,  // 14: kit.default.configv1.Registry.tls:type_name -> kit.default.configv1.TLS
+	39, // 15: kit.default.configv1.Retry.initial_backoff:type_name -> google.protobuf.Duration
+	39, // 16: kit.default.configv1.Retry.max_backoff:type_name -> google.protobuf.Duration
+	39, // 17: kit.default.configv1.CircuitBreaker.window:type_name -> google.protobuf.Duration
+	39, // 18: kit.default.configv1.CircuitBreaker.open_timeout:type_name -> google.protobuf.Duration
+	19, // 19: kit.default.configv1.Data.database:type_name -> kit.default.configv1.Database
+	17, // 20: kit.default.configv1.Data.redis:type_name -> kit.default.configv1.Redis
+	18, // 21: kit.default.configv1.Data.mongodb:type_name -> kit.default.configv1.Mongo
+	12, // 22: kit.default.configv1.Data.rabbitMq:type_name -> kit.default.configv1.RabbitMQ
+	39, // 23: kit.default.configv1.RabbitMQ.heartbeat:type_name -> google.protobuf.Duration
+	31, // 24: kit.default.configv1.Log.console:type_name -> kit.default.configv1.Log.Console
+	32, // 25: kit.default.configv1.Log.graylog:type_name -> kit.default.configv1.Log.Graylog
+	33, // 26: kit.default.configv1.Log.file:type_name -> kit.default.configv1.Log.File
+	34, // 27: kit.default.configv1.Log.payload:type_name -> kit.default.configv1.Log.Payload
+	39, // 28: kit.default.configv1.Redis.read_timeout:type_name -> google.protobuf.Duration
+	39, // 29: kit.default.configv1.Redis.write_timeout:type_name -> google.protobuf.Duration
+	39, // 30: kit.default.configv1.Redis.dial_timeout:type_name -> google.protobuf.Duration
+	39, // 31: kit.default.configv1.Mongo.connect_timeout:type_name -> google.protobuf.Duration
+	39, // 32: kit.default.configv1.Mongo.heartbeat_interval:type_name -> google.protobuf.Duration
+	39, // 33: kit.default.configv1.Mongo.max_conn_idle_time:type_name -> google.protobuf.Duration
+	39, // 34: kit.default.configv1.Mongo.timeout:type_name -> google.protobuf.Duration
+	39, // 35: kit.default.configv1.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
+	39, // 36: kit.default.configv1.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
+	35, // 37: kit.default.configv1.DatabaseInstances.instances:type_name -> kit.default.configv1.DatabaseInstances.InstancesEntry
+	36, // 38: kit.default.configv1.RedisInstances.instances:type_name -> kit.default.configv1.RedisInstances.InstancesEntry
+	37, // 39: kit.default.configv1.MongoInstances.instances:type_name -> kit.default.configv1.MongoInstances.InstancesEntry
+	39, // 40: kit.default.configv1.JWT.leeway:type_name -> google.protobuf.Duration
+	38, // 41: kit.default.configv1.Signature.trusted_keys:type_name -> kit.default.configv1.Signature.TrustedKeysEntry
+	39, // 42: kit.default.configv1.Signature.window:type_name -> google.protobuf.Duration
+	39, // 43: kit.default.configv1.Idempotency.ttl:type_name -> google.protobuf.Duration
+	39, // 44: kit.default.configv1.Idempotency.lock_timeout:type_name -> google.protobuf.Duration
+	29, // 45: kit.default.configv1.RateLimit.rules:type_name -> kit.default.configv1.RateLimitRule
+	39, // 46: kit.default.configv1.RateLimitRule.window:type_name -> google.protobuf.Duration
+	39, // 47: kit.default.configv1.Registry.OperationTimeoutsEntry.value:type_name -> google.protobuf.Duration
+	39, // 48: kit.default.configv1.Log.File.rotate_time:type_name -> google.protobuf.Duration
+	39, // 49: kit.default.configv1.Log.File.storage_age:type_name -> google.protobuf.Duration
+	19, // 50: kit.default.configv1.DatabaseInstances.InstancesEntry.value:type_name -> kit.default.configv1.Database
+	17, // 51: kit.default.configv1.RedisInstances.InstancesEntry.value:type_name -> kit.default.configv1.Redis
+	18, // 52: kit.default.configv1.MongoInstances.InstancesEntry.value:type_name -> kit.default.configv1.Mongo
+	53, // [53:53] is the sub-list for method output_type
+	53, // [53:53] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_config_def_default_v1_proto_init() }
@@ -1814,7 +3441,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tracing); i {
+			switch v := v.(*Admin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1826,7 +3453,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Registry); i {
+			switch v := v.(*Discovery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1838,7 +3465,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Data); i {
+			switch v := v.(*LoadShedding); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1850,7 +3477,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RabbitMQ); i {
+			switch v := v.(*Shutdown); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1862,7 +3489,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExchangeConfig); i {
+			switch v := v.(*Tracing); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1874,7 +3501,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueConfig); i {
+			switch v := v.(*Registry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1886,7 +3513,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConsumeConfig); i {
+			switch v := v.(*TLS); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1898,7 +3525,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Log); i {
+			switch v := v.(*Retry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1910,7 +3537,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Redis); i {
+			switch v := v.(*CircuitBreaker); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1922,7 +3549,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Mongo); i {
+			switch v := v.(*Data); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1934,7 +3561,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Database); i {
+			switch v := v.(*RabbitMQ); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1946,7 +3573,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Profile); i {
+			switch v := v.(*ExchangeConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1958,7 +3585,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Log_Console); i {
+			switch v := v.(*QueueConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1970,7 +3597,7 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Log_Graylog); i {
+			switch v := v.(*ConsumeConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1982,6 +3609,198 @@ func file_config_def_default_v1_proto_init() {
 			}
 		}
 		file_config_def_default_v1_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Log); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Redis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Mongo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Database); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatabaseInstances); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedisInstances); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MongoInstances); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Profile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Metrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JWT); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Idempotency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimitRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Log_Console); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Log_Graylog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_def_default_v1_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Log_File); i {
 			case 0:
 				return &v.state
@@ -1993,6 +3812,18 @@ func file_config_def_default_v1_proto_init() {
 				return nil
 			}
 		}
+		file_config_def_default_v1_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Log_Payload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2000,7 +3831,7 @@ func file_config_def_default_v1_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_config_def_default_v1_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   16,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -2,6 +2,7 @@ package inject
 
 import (
 	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
 	"github.com/eden-quan/go-biz-kit/injection"
 )
 
@@ -9,10 +10,11 @@ import (
 Inject 为项目注入 配置库
  1. NewConfigWithFiles 注入本地文件配置，本地文件配置包含应用配置及配置中心的地址，
     其他配置库都依赖于该基础组件，可通过 *config.LocalConfigure 获取
- 2. NewConfigWatcher 注入配置监听器, 他依赖 LocalConfigure 提供的配置中心地址连接到配置中心，
-    并提供了监听配置的能力，使用者可通过 *ConfigureWatcherRepo 获取
- 3. NewConfiguration 注入基础配置, 他依赖于 ConfigureWatcherRepo 为他提供监听能力，
-    并加载基础中间件配置，其中包括了数据库/日志等基础配置信息，使用者 *def.Configuration 获取基础配置信息
+ 2. NewConfigWatcher 注入配置监听器, 他依赖 NewEtcdClient 根据 LocalConfigure 提供的配置中心地址创建的 *etcd.Client，
+    并提供了监听配置的能力，使用者可通过 *ConfigureWatcherRepo 获取, 使用本地配置文件时 *etcd.Client 为 nil
+ 3. 基础配置 *def.Configuration 需要由使用者提供，无需自定义配置时可通过 InjectConfiguration 注入,
+    需要自定义配置时可通过 injection.ProvideTaggedConfig 提供嵌入了 def.Configuration 的配置结构体,
+    或通过 injection.ProvideConfig 为任意结构体提供指定路径的配置
 */
 func Inject() {
 	InjectIns(injection.GlobalInjector())
@@ -22,7 +24,28 @@ func Inject() {
 func InjectIns(inj *injection.Injector) {
 	inj.InjectMany(
 		config.NewConfigWithFiles,
+		config.NewEtcdClient,
 		config.NewConfigWatcher,
-		//def.NewConfiguration,
 	)
 }
+
+// InjectConfiguration 注入基础配置 *def.Configuration, 适用于无需自定义配置的服务,
+// 需要自定义配置的服务可以通过 injection.ProvideConfig / injection.ProvideTaggedConfig 提供配置
+func InjectConfiguration() {
+	InjectConfigurationIns(injection.GlobalInjector())
+}
+
+// InjectConfigurationIns 使用实例化的方式注入基础配置 *def.Configuration
+func InjectConfigurationIns(inj *injection.Injector) {
+	inj.Inject(def.NewConfiguration)
+}
+
+// Module 配置模块 config，提供 *config.LocalConfigure, *etcd.Client 及 config.ConfigureWatcherRepo
+func Module() *injection.Module {
+	return injection.NewModule("config").With(InjectIns)
+}
+
+// ConfigurationModule 基础配置模块 configuration，提供 *def.Configuration, 具体见 InjectConfiguration
+func ConfigurationModule() *injection.Module {
+	return injection.NewModule("configuration").Require("config").With(InjectConfigurationIns)
+}
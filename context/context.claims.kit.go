@@ -0,0 +1,81 @@
+package contextutil
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+type claimsKey struct{}
+
+// Claims 为通过 JWT 鉴权后的用户信息, 由 middlewareutil.NewJWTAuth 写入上下文
+type Claims struct {
+	UserID      string    // 用户 ID, 对应令牌中的 uid, 为空时使用 sub
+	Tenant      string    // 租户 ID, 对应令牌中的 tenant
+	Roles       []string  // 用户角色, 对应令牌中的 roles
+	Permissions []string  // 用户权限, 对应令牌中的 permissions
+	Issuer      string    // 令牌的签发者
+	Audience    []string  // 令牌的受众
+	ExpiresAt   time.Time // 令牌的过期时间
+}
+
+// WithClaims 创建一个带有用户信息的上下文
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// GetClaims 尝试从上下文中获取用户信息, 请求未经过 JWT 鉴权时返回 false
+func GetClaims(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	if !ok || claims == nil {
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// GetUserID 尝试从上下文中获取用户 ID
+func GetUserID(ctx context.Context) (string, bool) {
+	claims, ok := GetClaims(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return claims.UserID, claims.UserID != ""
+}
+
+// GetTenant 尝试从上下文中获取租户 ID
+func GetTenant(ctx context.Context) (string, bool) {
+	claims, ok := GetClaims(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return claims.Tenant, claims.Tenant != ""
+}
+
+// GetRoles 尝试从上下文中获取用户角色
+func GetRoles(ctx context.Context) ([]string, bool) {
+	claims, ok := GetClaims(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	return claims.Roles, len(claims.Roles) != 0
+}
+
+// GetPermissions 尝试从上下文中获取用户权限
+func GetPermissions(ctx context.Context) ([]string, bool) {
+	claims, ok := GetClaims(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	return claims.Permissions, len(claims.Permissions) != 0
+}
+
+// HasRole 判断当前用户是否拥有指定的角色
+func HasRole(ctx context.Context, role string) bool {
+	roles, _ := GetRoles(ctx)
+	return slices.Contains(roles, role)
+}
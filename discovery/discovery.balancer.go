@@ -0,0 +1,71 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/selector/p2c"
+	"github.com/go-kratos/kratos/v2/selector/wrr"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+const (
+	// BalancerRoundRobin 轮询, 实例未配置权重时按顺序依次调用
+	BalancerRoundRobin = "round_robin"
+	// BalancerP2C 随机选择两个实例, 优先调用延迟及处理中请求更少的实例
+	BalancerP2C = "p2c"
+)
+
+var setupOnce sync.Once
+
+type balancerKey struct{}
+
+// SetupBalancer 通过 selector.SetGlobalSelector 替换 Kratos 进程级的全局选择器, 使不同的客户端可以使用不同的负载均衡策略,
+// 替换会影响进程内所有之后创建的 Kratos 客户端 (包括不是由本库创建的客户端), 未经过 Balancer 中间件的调用使用 BalancerRoundRobin,
+// 其他代码再次调用 SetGlobalSelector 时会覆盖此处的设置, 使 Balancer 配置的策略失效.
+// Kratos 的 HTTP 客户端在创建时获取全局选择器, 因此需要在创建客户端前调用, 只有首次调用生效
+func SetupBalancer() {
+	setupOnce.Do(func() {
+		selector.SetGlobalSelector(&balancerBuilder{})
+	})
+}
+
+// Balancer 返回为调用指定负载均衡策略的客户端中间件, conf 返回目标服务的服务发现配置, 支持动态调整, 未知的策略使用 BalancerRoundRobin
+func Balancer(conf func() *def.Discovery) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return handler(context.WithValue(ctx, balancerKey{}, conf().GetBalancer()), req)
+		}
+	}
+}
+
+type balancerBuilder struct{}
+
+func (b *balancerBuilder) Build() selector.Selector {
+	return &balancerSelector{
+		roundRobin: wrr.New(),
+		p2c:        p2c.New(),
+	}
+}
+
+// balancerSelector 同时维护所有策略的实例列表, 调用时根据 Balancer 中间件写入的策略选择实例
+type balancerSelector struct {
+	roundRobin selector.Selector
+	p2c        selector.Selector
+}
+
+func (s *balancerSelector) Apply(nodes []selector.Node) {
+	s.roundRobin.Apply(nodes)
+	s.p2c.Apply(nodes)
+}
+
+func (s *balancerSelector) Select(ctx context.Context, opts ...selector.SelectOption) (selector.Node, selector.DoneFunc, error) {
+	if name, _ := ctx.Value(balancerKey{}).(string); name == BalancerP2C {
+		return s.p2c.Select(ctx, opts...)
+	}
+
+	return s.roundRobin.Select(ctx, opts...)
+}
@@ -0,0 +1,87 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// namedSelector 记录被调用的次数, 用于确认调用使用的策略
+type namedSelector struct {
+	node    selector.Node
+	applied int
+	selects int
+}
+
+func (s *namedSelector) Apply(nodes []selector.Node) { s.applied = len(nodes) }
+
+func (s *namedSelector) Select(context.Context, ...selector.SelectOption) (selector.Node, selector.DoneFunc, error) {
+	s.selects++
+	return s.node, func(context.Context, selector.DoneInfo) {}, nil
+}
+
+// go test -v -count=1 ./discovery -test.run=TestBalancer
+func TestBalancer(t *testing.T) {
+	nodes := make([]selector.Node, 0)
+	for _, addr := range []string{"10.0.0.1:9000", "10.0.0.2:9000", "10.0.0.3:9000"} {
+		nodes = append(nodes, selector.NewNode("grpc", addr, &registry.ServiceInstance{ID: addr, Name: "user-service"}))
+	}
+
+	roundRobin := &namedSelector{node: nodes[0]}
+	p2c := &namedSelector{node: nodes[1]}
+	s := &balancerSelector{roundRobin: roundRobin, p2c: p2c}
+	s.Apply(nodes)
+	require.Equal(t, 3, roundRobin.applied)
+	require.Equal(t, 3, p2c.applied)
+
+	// 根据 Balancer 中间件写入的策略选择实例, 配置修改后立即生效, 未知的策略使用轮询
+	conf := &def.Discovery{}
+	pick := func() selector.Node {
+		var picked selector.Node
+		_, err := Balancer(func() *def.Discovery { return conf })(func(ctx context.Context, _ interface{}) (interface{}, error) {
+			node, _, err := s.Select(ctx)
+			picked = node
+			return nil, err
+		})(context.Background(), nil)
+		require.NoError(t, err)
+		return picked
+	}
+
+	require.Equal(t, nodes[0], pick())
+	conf.Balancer = BalancerP2C
+	require.Equal(t, nodes[1], pick())
+	conf.Balancer = "least_conn"
+	require.Equal(t, nodes[0], pick())
+	require.Equal(t, 2, roundRobin.selects)
+	require.Equal(t, 1, p2c.selects)
+
+	_, _, err := s.Select(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, roundRobin.selects)
+
+	// 全局选择器被替换后, Kratos 客户端创建的选择器同时维护两种策略
+	SetupBalancer()
+	built, ok := selector.GlobalSelector().Build().(*balancerSelector)
+	require.True(t, ok)
+	built.Apply(nodes)
+
+	picked := make(map[string]int)
+	for i := 0; i < 6; i++ {
+		node, done, err := built.Select(context.Background())
+		require.NoError(t, err)
+		done(context.Background(), selector.DoneInfo{})
+		picked[node.Address()]++
+	}
+	require.Equal(t, map[string]int{"10.0.0.1:9000": 2, "10.0.0.2:9000": 2, "10.0.0.3:9000": 2}, picked)
+
+	ctx := context.WithValue(context.Background(), balancerKey{}, BalancerP2C)
+	node, done, err := built.Select(ctx)
+	require.NoError(t, err)
+	done(ctx, selector.DoneInfo{})
+	require.Contains(t, []string{"10.0.0.1:9000", "10.0.0.2:9000", "10.0.0.3:9000"}, node.Address())
+}
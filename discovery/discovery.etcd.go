@@ -0,0 +1,249 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+const (
+	// DefaultNamespace 服务实例在 ETCD 中的默认前缀
+	DefaultNamespace = "/microservices"
+	// DefaultTTL 注册信息的默认租约时间
+	DefaultTTL = time.Second * 15
+
+	// 租约失效后重新注册的最大等待时间
+	maxRetryBackoff = time.Second * 10
+)
+
+// ErrClientRequired 启用了服务发现但没有可用的 ETCD 客户端 (使用本地配置文件启动) 时返回该错误
+var ErrClientRequired = errors.New("service discovery requires the config center (etcd) client, but local_file is used")
+
+var (
+	_ registry.Registrar = (*EtcdRegistry)(nil)
+	_ registry.Discovery = (*EtcdRegistry)(nil)
+)
+
+// EtcdRegistry 基于 ETCD 实现的服务注册与发现, 服务实例以 JSON 格式保存在 {namespace}/{name}/{id} 下,
+// 注册信息绑定在租约上, 服务异常退出后超过 ttl 会被自动摘除
+type EtcdRegistry struct {
+	client    *etcd.Client
+	namespace string
+	ttl       time.Duration
+	logger    *log.Helper
+
+	lock    sync.Mutex
+	cancels map[string]context.CancelFunc // 每个已注册实例的续约协程
+}
+
+// NewEtcdRegistry 根据服务发现配置创建基于 ETCD 的服务注册与发现, 未配置的项使用默认值
+func NewEtcdRegistry(client *etcd.Client, conf *def.Discovery, logger log.Logger) *EtcdRegistry {
+	namespace := conf.GetNamespace()
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	ttl := conf.GetTtl().AsDuration()
+	if ttl < time.Second {
+		ttl = DefaultTTL
+	}
+
+	return &EtcdRegistry{
+		client:    client,
+		namespace: namespace,
+		ttl:       ttl,
+		logger:    log.NewHelper(log.With(logger, "module", "discovery")),
+		cancels:   make(map[string]context.CancelFunc),
+	}
+}
+
+// Register 将服务实例注册到 ETCD, 并在后台持续续约, 租约丢失 (如与 ETCD 断开超过 ttl) 时会自动重新注册
+func (r *EtcdRegistry) Register(ctx context.Context, service *registry.ServiceInstance) error {
+	key := r.instanceKey(service)
+	value, err := json.Marshal(service)
+	if err != nil {
+		return err
+	}
+
+	leaseID, err := r.put(ctx, key, string(value))
+	if err != nil {
+		return err
+	}
+
+	// 续约需要在服务的整个生命周期内进行, 不能使用 ctx
+	hctx, cancel := context.WithCancel(context.Background())
+
+	r.lock.Lock()
+	if prev, ok := r.cancels[key]; ok {
+		prev()
+	}
+	r.cancels[key] = cancel
+	r.lock.Unlock()
+
+	go r.keepAlive(hctx, key, string(value), leaseID)
+	return nil
+}
+
+// Deregister 停止续约并从 ETCD 中删除服务实例
+func (r *EtcdRegistry) Deregister(ctx context.Context, service *registry.ServiceInstance) error {
+	key := r.instanceKey(service)
+
+	r.lock.Lock()
+	if cancel, ok := r.cancels[key]; ok {
+		cancel()
+		delete(r.cancels, key)
+	}
+	r.lock.Unlock()
+
+	_, err := r.client.Delete(ctx, key)
+	return err
+}
+
+// GetService 返回服务 serviceName 当前的所有实例
+func (r *EtcdRegistry) GetService(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	resp, err := r.client.Get(ctx, r.serviceKey(serviceName), etcd.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*registry.ServiceInstance, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		si := &registry.ServiceInstance{}
+		if err := json.Unmarshal(kv.Value, si); err != nil {
+			r.logger.Warnf("skip invalid service instance %s with error %s", kv.Key, err)
+			continue
+		}
+		if si.Name != serviceName {
+			continue
+		}
+		items = append(items, si)
+	}
+
+	return items, nil
+}
+
+// Watch 监听服务 serviceName 的实例变化
+func (r *EtcdRegistry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	return newWatcher(ctx, r, serviceName), nil
+}
+
+// put 创建租约并写入实例信息
+func (r *EtcdRegistry) put(ctx context.Context, key, value string) (etcd.LeaseID, error) {
+	grant, err := r.client.Grant(ctx, int64(r.ttl/time.Second))
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = r.client.Put(ctx, key, value, etcd.WithLease(grant.ID)); err != nil {
+		return 0, err
+	}
+
+	return grant.ID, nil
+}
+
+// keepAlive 持续为 leaseID 续约, 续约中断时使用退避重试重新注册, 直到 ctx 被取消
+func (r *EtcdRegistry) keepAlive(ctx context.Context, key, value string, leaseID etcd.LeaseID) {
+	backoff := time.Second
+
+	for {
+		if leaseID != 0 {
+			ch, err := r.client.KeepAlive(ctx, leaseID)
+			if err == nil {
+				backoff = time.Second
+				for range ch {
+				}
+			}
+
+			if ctx.Err() != nil {
+				// 服务退出时主动释放租约, 使实例立即摘除
+				revokeCtx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				_, _ = r.client.Revoke(revokeCtx, leaseID)
+				cancel()
+				return
+			}
+
+			r.logger.Warnf("lease of %s lost, registering again", key)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff + time.Duration(rand.Int63n(int64(backoff)))):
+		}
+
+		var err error
+		if leaseID, err = r.put(ctx, key, value); err != nil {
+			r.logger.Errorf("register %s failed with error %s", key, err)
+			leaseID = 0
+			backoff = min(backoff*2, maxRetryBackoff)
+		}
+	}
+}
+
+func (r *EtcdRegistry) serviceKey(serviceName string) string {
+	return fmt.Sprintf("%s/%s/", r.namespace, serviceName)
+}
+
+func (r *EtcdRegistry) instanceKey(service *registry.ServiceInstance) string {
+	return r.serviceKey(service.Name) + service.ID
+}
+
+// watcher 监听服务前缀下的变化, 每次变化时返回完整的实例列表
+type watcher struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	registry    *EtcdRegistry
+	serviceName string
+	watchChan   etcd.WatchChan
+	first       bool
+}
+
+func newWatcher(ctx context.Context, r *EtcdRegistry, serviceName string) *watcher {
+	w := &watcher{
+		registry:    r,
+		serviceName: serviceName,
+		first:       true,
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	w.watchChan = r.client.Watch(w.ctx, r.serviceKey(serviceName), etcd.WithPrefix(), etcd.WithRev(0))
+
+	return w
+}
+
+// Next 首次调用时立即返回当前的实例列表, 之后阻塞直到实例发生变化
+func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
+	if w.first {
+		w.first = false
+		return w.registry.GetService(w.ctx, w.serviceName)
+	}
+
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case resp, ok := <-w.watchChan:
+		if !ok || resp.Err() != nil {
+			if w.ctx.Err() != nil {
+				return nil, w.ctx.Err()
+			}
+			// 监听中断 (如 compact) 时重新建立监听, 并返回最新的实例列表, 避免丢失中断期间的变化
+			w.watchChan = w.registry.client.Watch(w.ctx, w.registry.serviceKey(w.serviceName), etcd.WithPrefix())
+		}
+		return w.registry.GetService(w.ctx, w.serviceName)
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
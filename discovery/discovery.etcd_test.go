@@ -0,0 +1,259 @@
+package discovery
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	etcd "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// fakeEtcd 在内存中实现服务注册与发现使用的 ETCD 接口, 不需要启动 ETCD
+type fakeEtcd struct {
+	etcd.KV
+	etcd.Lease
+	etcd.Watcher
+
+	lock      sync.Mutex
+	values    map[string]string
+	leases    map[string]etcd.LeaseID // key 绑定的租约
+	ttls      map[etcd.LeaseID]int64
+	nextLease etcd.LeaseID
+	alive     map[etcd.LeaseID]bool // 正在续约的租约
+	revoked   []etcd.LeaseID
+	watchers  map[chan etcd.WatchResponse]string
+}
+
+func newFakeEtcd() (*fakeEtcd, *etcd.Client) {
+	f := &fakeEtcd{
+		values:   map[string]string{},
+		leases:   map[string]etcd.LeaseID{},
+		ttls:     map[etcd.LeaseID]int64{},
+		alive:    map[etcd.LeaseID]bool{},
+		watchers: map[chan etcd.WatchResponse]string{},
+	}
+
+	client := etcd.NewCtxClient(context.Background())
+	client.KV, client.Lease, client.Watcher = f, f, f
+
+	return f, client
+}
+
+func (f *fakeEtcd) Close() error { return nil }
+
+func (f *fakeEtcd) Put(_ context.Context, key, val string, opts ...etcd.OpOption) (*etcd.PutResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Op 没有公开租约, 通过反射读取
+	op := etcd.OpPut(key, val, opts...)
+	f.values[key] = val
+	f.leases[key] = etcd.LeaseID(reflect.ValueOf(op).FieldByName("leaseID").Int())
+	f.notify(key)
+
+	return &etcd.PutResponse{}, nil
+}
+
+func (f *fakeEtcd) Get(_ context.Context, key string, opts ...etcd.OpOption) (*etcd.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	keys := make([]string, 0)
+	for k := range f.values {
+		if k == key || (etcd.IsOptsWithPrefix(opts) && strings.HasPrefix(k, key)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	resp := &etcd.GetResponse{}
+	for _, k := range keys {
+		resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(k), Value: []byte(f.values[k])})
+	}
+
+	return resp, nil
+}
+
+func (f *fakeEtcd) Delete(_ context.Context, key string, _ ...etcd.OpOption) (*etcd.DeleteResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.delete(key)
+	return &etcd.DeleteResponse{}, nil
+}
+
+func (f *fakeEtcd) Grant(_ context.Context, ttl int64) (*etcd.LeaseGrantResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.nextLease++
+	f.ttls[f.nextLease] = ttl
+	return &etcd.LeaseGrantResponse{ID: f.nextLease, TTL: ttl}, nil
+}
+
+func (f *fakeEtcd) Revoke(_ context.Context, id etcd.LeaseID) (*etcd.LeaseRevokeResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.revoked = append(f.revoked, id)
+	for key, lease := range f.leases {
+		if lease == id {
+			f.delete(key)
+		}
+	}
+
+	return &etcd.LeaseRevokeResponse{}, nil
+}
+
+// KeepAlive 与 ETCD 客户端一致, ctx 取消后关闭返回的 channel
+func (f *fakeEtcd) KeepAlive(ctx context.Context, id etcd.LeaseID) (<-chan *etcd.LeaseKeepAliveResponse, error) {
+	f.lock.Lock()
+	f.alive[id] = true
+	f.lock.Unlock()
+
+	ch := make(chan *etcd.LeaseKeepAliveResponse)
+	go func() {
+		<-ctx.Done()
+
+		f.lock.Lock()
+		delete(f.alive, id)
+		f.lock.Unlock()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func (f *fakeEtcd) Watch(ctx context.Context, key string, _ ...etcd.OpOption) etcd.WatchChan {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	ch := make(chan etcd.WatchResponse, 16)
+	f.watchers[ch] = key
+	go func() {
+		<-ctx.Done()
+
+		f.lock.Lock()
+		delete(f.watchers, ch)
+		f.lock.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (f *fakeEtcd) delete(key string) {
+	if _, ok := f.values[key]; !ok {
+		return
+	}
+
+	delete(f.values, key)
+	delete(f.leases, key)
+	f.notify(key)
+}
+
+func (f *fakeEtcd) notify(key string) {
+	for ch, prefix := range f.watchers {
+		if strings.HasPrefix(key, prefix) {
+			select {
+			case ch <- etcd.WatchResponse{}:
+			default:
+			}
+		}
+	}
+}
+
+func (f *fakeEtcd) isAlive(id etcd.LeaseID) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.alive[id]
+}
+
+// go test -v -count=1 ./discovery -test.run=TestEtcdRegistry
+func TestEtcdRegistry(t *testing.T) {
+	fake, client := newFakeEtcd()
+	r := NewEtcdRegistry(client, &def.Discovery{}, log.DefaultLogger)
+	ctx := context.Background()
+
+	instance := func(id string, endpoint string) *registry.ServiceInstance {
+		return &registry.ServiceInstance{ID: id, Name: "user-service", Version: "v1", Endpoints: []string{endpoint}}
+	}
+	ids := func(items []*registry.ServiceInstance) []string {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.ID)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	// 注册时绑定默认租约并开始续约
+	first := instance("1", "grpc://10.0.0.1:9000")
+	require.NoError(t, r.Register(ctx, first))
+	require.Contains(t, fake.values, "/microservices/user-service/1")
+	lease := fake.leases["/microservices/user-service/1"]
+	require.Equal(t, int64(DefaultTTL/time.Second), fake.ttls[lease])
+	require.Eventually(t, func() bool { return fake.isAlive(lease) }, time.Second, time.Millisecond*10)
+
+	// 其他服务的实例及无法解析的内容不会返回
+	require.NoError(t, NewEtcdRegistry(client, &def.Discovery{}, log.DefaultLogger).Register(ctx,
+		&registry.ServiceInstance{ID: "1", Name: "user-service-admin"}))
+	fake.values["/microservices/user-service/broken"] = "{"
+
+	items, err := r.GetService(ctx, "user-service")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, ids(items))
+	require.Equal(t, []string{"grpc://10.0.0.1:9000"}, items[0].Endpoints)
+
+	// 首次 Next 立即返回当前的实例, 之后在实例变化时返回完整的实例列表
+	w, err := r.Watch(ctx, "user-service")
+	require.NoError(t, err)
+	items, err = w.Next()
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, ids(items))
+
+	second := instance("2", "grpc://10.0.0.2:9000")
+	require.NoError(t, r.Register(ctx, second))
+	secondLease := fake.leases["/microservices/user-service/2"]
+	items, err = w.Next()
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, ids(items))
+
+	// 注销时停止续约并删除实例, 租约被释放
+	require.NoError(t, r.Deregister(ctx, second))
+	items, err = w.Next()
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, ids(items))
+
+	require.Eventually(t, func() bool {
+		fake.lock.Lock()
+		defer fake.lock.Unlock()
+		return len(fake.revoked) == 1 && fake.revoked[0] == secondLease
+	}, time.Second*5, time.Millisecond*10)
+	require.True(t, fake.isAlive(lease))
+
+	// 停止监听后 Next 返回错误
+	require.NoError(t, w.Stop())
+	_, err = w.Next()
+	require.ErrorIs(t, err, context.Canceled)
+
+	// 配置的命名空间及租约时间
+	custom := NewEtcdRegistry(client, &def.Discovery{Namespace: "/services", Ttl: durationpb.New(time.Second * 30)}, log.DefaultLogger)
+	require.NoError(t, custom.Register(ctx, instance("3", "grpc://10.0.0.3:9000")))
+	require.Contains(t, fake.values, "/services/user-service/3")
+	require.Equal(t, int64(30), fake.ttls[fake.leases["/services/user-service/3"]])
+	require.NoError(t, custom.Deregister(ctx, instance("3", "")))
+	require.NotContains(t, fake.values, "/services/user-service/3")
+}
@@ -1,4 +1,30 @@
 package errorutil
 
+import (
+	stderrors "errors"
+	"strconv"
+
+	errorpkg "github.com/eden-quan/go-kratos-pkg/error"
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
 var DBTxError *ErrorsCode = NewErrorCode(500, 10000001, "create transaction failed")
 var DBGetError *ErrorsCode = NewErrorCode(500, 10000002, "create db connection failed")
+var ComponentDisabled *ErrorsCode = NewErrorCode(503, 10000003, "component disabled")
+
+// IsComponentDisabled 检查 err 是否为组件未启用产生的错误
+func IsComponentDisabled(err error) bool {
+	return err != nil && errors.Reason(err) == ComponentDisabled.Reason
+}
+
+// BizCode 返回错误链中的业务错误码, ErrorsCode 生成的错误将业务错误码保存在 cause 的元数据中, 不存在时返回 0
+func BizCode(err error) int {
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		if e, ok := err.(*errors.Error); ok && e.Metadata[errorpkg.BizCodeKey] != "" {
+			code, _ := strconv.Atoi(e.Metadata[errorpkg.BizCodeKey])
+			return code
+		}
+	}
+
+	return 0
+}
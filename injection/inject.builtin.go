@@ -1,8 +1,10 @@
 package injection
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"go.uber.org/fx"
@@ -14,40 +16,100 @@ func injectEntrance(_ *injectDep) {
 	//log.NewHelper(logger).Info("Injected service finished...")
 }
 
-func collectServiceProvider(logger log.Logger, httpRegister []fmt.Stringer, grpcRegister []fmt.Stringer) *injectDep {
+// RegisteredServices 为通过 http_register / grpc_register 注册到容器中的服务, 可用于管理端点等需要展示服务列表的场景
+type RegisteredServices struct {
+	HTTP []string // HTTP 为已注册的 HTTP 服务
+	GRPC []string // GRPC 为已注册的 GRPC 服务
+}
+
+func collectServiceProvider(logger log.Logger, httpRegister []fmt.Stringer, grpcRegister []fmt.Stringer) (*injectDep, *RegisteredServices) {
 	helper := log.NewHelper(logger)
 	helper.Info("\tinject http: ", httpRegister)
 	helper.Info("\tinject grpc: ", grpcRegister)
-	return &injectDep{}
+
+	services := &RegisteredServices{}
+	for _, s := range httpRegister {
+		services.HTTP = append(services.HTTP, s.String())
+	}
+	for _, s := range grpcRegister {
+		services.GRPC = append(services.GRPC, s.String())
+	}
+
+	return &injectDep{}, services
 }
 
 type graphOption struct {
 	Output string
+	Report bool
 }
 
-func (g *graphOption) Provide() fx.Option {
-	return fx.Invoke(func(dot fx.DotGraph) {
-		f, err := os.OpenFile(g.Output, os.O_WRONLY|os.O_CREATE, 0644)
+type graphReportParam struct {
+	fx.In
 
-		if err == nil {
-			_, err = f.WriteString(string(dot))
-		}
+	Dot       fx.DotGraph
+	Lifecycle fx.Lifecycle
+	Report    *StartupReport `optional:"true"`
+}
 
-		defer func() {
-			_ = f.Close()
-		}()
+func (g *graphOption) Provide() fx.Option {
+	if g.Report {
+		// 启动报告需要在所有依赖构造完成后才完整，因此在启动阶段输出
+		return fx.Invoke(func(p graphReportParam) {
+			p.Lifecycle.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					return g.write(string(p.Dot) + reportComment(p.Report))
+				},
+			})
+		})
+	}
 
+	return fx.Invoke(func(dot fx.DotGraph) {
+		err := g.write(string(dot))
 		if err != nil {
 			fx.Error(err)
 		}
 	})
 }
 
+func (g *graphOption) write(content string) error {
+	f, err := os.OpenFile(g.Output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	_, err = f.WriteString(content)
+	return err
+}
+
+// reportComment 将启动报告转换为 DOT 格式的注释
+func reportComment(report *StartupReport) string {
+	if report == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("\n")
+	for _, line := range strings.Split(report.String(), "\n") {
+		builder.WriteString("// " + strings.TrimSpace(line) + "\n")
+	}
+
+	return builder.String()
+}
+
 // WithGraph 在启动时将依赖信息输出到 output 指定的文件中
 func WithGraph(output string) Option {
 	return &graphOption{Output: output}
 }
 
+// WithGraphReport 在启动完成后将依赖信息及启动报告输出到 output 指定的文件中, 启动报告以注释的形式附加在 DOT 内容之后
+func WithGraphReport(output string) Option {
+	return &graphOption{Output: output, Report: true}
+}
+
 // invokeOption 用于在完成依赖注入后触发指定的函数调用，触发的调用需要依赖
 // 已注入的组件
 type invokeOption struct {
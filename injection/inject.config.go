@@ -0,0 +1,55 @@
+package injection
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eden-quan/go-biz-kit/config"
+)
+
+// ProvideConfig 返回从配置中心 path 加载 T 的构造函数, 消费者获取的 *T 会随配置中心的变更实时更新,
+// path 在配置中心不存在时会导致启动失败, 如:
+// inj.Inject(injection.ProvideConfig[conf.OrderConfig]("/service/order/config"))
+// 之后可通过 *conf.OrderConfig 获取配置
+func ProvideConfig[T any](path string) func(repo config.ConfigureWatcherRepo) (*T, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return func(repo config.ConfigureWatcherRepo) (*T, error) {
+		obj := new(T)
+
+		err := repo.LoadWithPath(obj, path)
+		if err == nil {
+			err = repo.Start()
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("[Config] loading %T from path %s failed with error %s, please check the config center (etcd)", obj, path, err)
+		}
+
+		return obj, nil
+	}
+}
+
+// ProvideTaggedConfig 返回加载 T 的构造函数, T 为使用 conf_path / conf_service 标签描述配置路径的结构体 (如 def.Configuration),
+// 所有标签指定的路径都会建立监听并实时更新, 如:
+//
+//	type OrderConfiguration struct {
+//		def.Configuration `conf_service:"order"`
+//		Order OrderConfig `conf_path:"/order/config"`
+//	}
+//
+// inj.Inject(injection.ProvideTaggedConfig[OrderConfiguration]())
+func ProvideTaggedConfig[T any]() func(repo config.ConfigureWatcherRepo) (*T, error) {
+	return func(repo config.ConfigureWatcherRepo) (*T, error) {
+		obj := new(T)
+
+		err := repo.LoadAndStart(obj)
+		if err != nil {
+			return nil, fmt.Errorf("[Config] loading %T failed with error %s, please check the config center (etcd)", obj, err)
+		}
+
+		return obj, nil
+	}
+}
@@ -0,0 +1,224 @@
+package injection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+const (
+	TimingKindProvide  = "provide"
+	TimingKindDecorate = "decorate"
+	TimingKindInvoke   = "invoke"
+	TimingKindHook     = "hook"
+)
+
+// ProviderTiming 为单个依赖在启动过程中的耗时信息
+type ProviderTiming struct {
+	Name     string        // Name 为构造函数的名称
+	Kind     string        // Kind 为耗时的类型, 见 TimingKind***
+	Duration time.Duration // Duration 为构造耗时，不包含其依赖的构造时间
+	Err      error         // Err 为构造过程中产生的错误
+}
+
+// StartupReport 记录启动过程中每个依赖的构造耗时、错误以及从未被使用的依赖, 用于分析服务启动缓慢的原因.
+// 由于依赖的构造是串行进行的，构造耗时通过相邻事件的时间间隔计算, 依赖的参数在构造函数调用前已完成构造，因此耗时不包含依赖的构造时间
+type StartupReport struct {
+	lock     sync.Mutex
+	inner    fxevent.Logger
+	last     time.Time
+	provided map[string][]string
+	run      map[string]bool
+	timings  []ProviderTiming
+}
+
+func newStartupReport(inner fxevent.Logger) *StartupReport {
+	return &StartupReport{
+		inner:    inner,
+		last:     time.Now(),
+		provided: make(map[string][]string),
+		run:      make(map[string]bool),
+		timings:  make([]ProviderTiming, 0),
+	}
+}
+
+// LogEvent 实现 fxevent.Logger, 记录事件后会将事件转发给原有的 Logger
+func (r *StartupReport) LogEvent(event fxevent.Event) {
+	r.record(event)
+
+	if r.inner != nil {
+		r.inner.LogEvent(event)
+	}
+}
+
+func (r *StartupReport) record(event fxevent.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	switch e := event.(type) {
+	case *fxevent.Provided:
+		r.provided[e.ConstructorName] = e.OutputTypeNames
+		if e.Err != nil {
+			r.timings = append(r.timings, ProviderTiming{Name: e.ConstructorName, Kind: TimingKindProvide, Err: e.Err})
+		}
+	case *fxevent.Invoking:
+		r.last = now
+	case *fxevent.Run:
+		kind := TimingKindProvide
+		if e.Kind == "decorate" {
+			kind = TimingKindDecorate
+		}
+		r.run[e.Name] = true
+		r.timings = append(r.timings, ProviderTiming{Name: e.Name, Kind: kind, Duration: now.Sub(r.last), Err: e.Err})
+		r.last = now
+	case *fxevent.Invoked:
+		r.timings = append(r.timings, ProviderTiming{Name: e.FunctionName, Kind: TimingKindInvoke, Duration: now.Sub(r.last), Err: e.Err})
+		r.last = now
+	case *fxevent.OnStartExecuted:
+		r.timings = append(r.timings, ProviderTiming{Name: e.CallerName, Kind: TimingKindHook, Duration: e.Runtime, Err: e.Err})
+	}
+}
+
+// Timings 返回按耗时从高到低排序的构造信息
+func (r *StartupReport) Timings() []ProviderTiming {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	timings := make([]ProviderTiming, len(r.timings))
+	copy(timings, r.timings)
+
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Duration > timings[j].Duration
+	})
+
+	return timings
+}
+
+// Unused 返回已注入但从未被构造的依赖, 框架内部按需使用的依赖不会被统计
+func (r *StartupReport) Unused() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	names := make([]string, 0)
+	for name := range r.provided {
+		if r.run[name] || isBuiltinProvider(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// String 将报告格式化为文本, 每一行为一条记录
+func (r *StartupReport) String() string {
+	lines := r.lines()
+
+	texts := make([]string, 0, len(lines))
+	for _, l := range lines {
+		texts = append(texts, l.text)
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+// Log 通过 logger 输出报告, 构造失败的依赖以 Error 级别输出，未使用的依赖以 Warn 级别输出
+func (r *StartupReport) Log(logger log.Logger) {
+	helper := log.NewHelper(logger)
+	for _, l := range r.lines() {
+		helper.Log(l.level, "msg", l.text)
+	}
+}
+
+// reportLine 为报告中的一行记录及其日志级别
+type reportLine struct {
+	level log.Level
+	text  string
+}
+
+func (r *StartupReport) lines() []reportLine {
+	timings := r.Timings()
+
+	var total time.Duration
+	for _, t := range timings {
+		total += t.Duration
+	}
+
+	lines := []reportLine{{
+		level: log.LevelInfo,
+		text:  fmt.Sprintf("startup report: %d records, total %s", len(timings), total),
+	}}
+
+	for _, t := range timings {
+		l := reportLine{
+			level: log.LevelInfo,
+			text:  fmt.Sprintf("\t%12s  %-8s  %s", t.Duration.Round(time.Microsecond), t.Kind, t.Name),
+		}
+		if t.Err != nil {
+			l.level = log.LevelError
+			l.text += fmt.Sprintf("  error: %s", t.Err)
+		}
+		lines = append(lines, l)
+	}
+
+	for _, name := range r.Unused() {
+		lines = append(lines, reportLine{level: log.LevelWarn, text: "\tunused provider: " + name})
+	}
+
+	return lines
+}
+
+// isBuiltinProvider 检查 name 是否为框架内部提供的依赖，这些依赖按需使用
+func isBuiltinProvider(name string) bool {
+	builtins := []string{
+		"github.com/eden-quan/go-biz-kit/injection.(*Injector).",
+		"github.com/eden-quan/go-biz-kit/injection.DefaultLoggerProvider",
+	}
+
+	if strings.HasPrefix(name, "go.uber.org/fx") {
+		return true
+	}
+
+	for _, b := range builtins {
+		if strings.Contains(name, b) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type startupReportParam struct {
+	fx.In
+
+	Lifecycle     fx.Lifecycle
+	DefaultLogger log.Logger `name:"logger"`
+	Logger        log.Logger `optional:"true"`
+}
+
+// reportInvoker 在所有依赖启动完成后通过日志输出启动报告, 如 fxLogger 仍未绑定日志实例，则使用相同的日志实例输出缓存的事件
+func reportInvoker(report *StartupReport, fxLogger *FxLogger) interface{} {
+	return func(p startupReportParam) {
+		logger := p.Logger
+		if logger == nil {
+			logger = p.DefaultLogger
+		}
+
+		p.Lifecycle.Append(fx.Hook{
+			OnStart: func(_ context.Context) error {
+				fxLogger.Flush(logger)
+				report.Log(logger)
+				return nil
+			},
+		})
+	}
+}
@@ -30,10 +30,17 @@ func InjectHTTPClient(provider interface{}) {
 	globalInjector.InjectHTTPClient(provider)
 }
 
+// InjectMiddleware 注入自定义的服务端中间件, 可通过 opts 指定中间件的阶段、优先级、生效的协议及需要匹配的 Operation
+func InjectMiddleware(mid interface{}, opts ...MiddlewareOption) {
+	globalInjector.InjectMiddleware(mid, opts...)
+}
+
+// InjectGRPCMiddleware 注入只对 GRPC 生效的自定义中间件
 func InjectGRPCMiddleware(mid interface{}) {
 	globalInjector.InjectGRPCMiddleware(mid)
 }
 
+// InjectHTTPMiddleware 注入只对 HTTP 生效的自定义中间件
 func InjectHTTPMiddleware(mid interface{}) {
 	globalInjector.InjectHTTPMiddleware(mid)
 }
@@ -77,6 +84,11 @@ func InjectWithParam(anno interface{}, params []string, results []string) {
 	globalInjector.InjectWithParam(anno, params, results)
 }
 
+// Install 按照模块间的依赖顺序将 modules 安装到全局注入器中, 具体见 Injector.Install
+func Install(modules ...*Module) error {
+	return globalInjector.Install(modules...)
+}
+
 func GlobalInjector() *Injector {
 	return &globalInjector
 }
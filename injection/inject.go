@@ -1,14 +1,18 @@
 package injection
 
 import (
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
 )
 
 type Injector struct {
 	cache      injectCacheIns
 	app        *fx.App
 	middleware *MiddlewareCollector
+	fxLogger   *FxLogger
+	modules    map[string]bool
 	count      int
 
 	bizCache map[interface{}]any
@@ -35,8 +39,42 @@ type Option interface {
 	Provide() fx.Option
 }
 
-// DoIt 按照已收集的依赖信息启动注入, 该函数会阻塞当前线程，如启动后需要执行其他功能，请使用 goroutine 执行
+// DoIt 按照已收集的依赖信息启动注入, 该函数会阻塞当前线程，如启动后需要执行其他功能，请使用 goroutine 执行,
+// 启动完成后会输出每个依赖的构造耗时及未被使用的依赖, 具体见 StartupReport, 退出时的超时时间可通过 StopTimeout 延长
 func (inj *Injector) DoIt(opts ...Option) {
+	options := inj.Options(opts...)
+
+	// 启动报告会记录每个依赖的构造耗时及错误，并在启动完成后输出, fx 的事件最终通过 FxLogger 输出到日志中
+	report := newStartupReport(inj.fxLogger)
+	stopTimeout := new(StopTimeout)
+	options = append(options,
+		fx.WithLogger(func() fxevent.Logger { return report }),
+		fx.Supply(report),
+		fx.Invoke(reportInvoker(report, inj.fxLogger)),
+		fx.Invoke(func(p stopTimeoutParam) { *stopTimeout = p.Timeout }),
+	)
+
+	//options = append(options, fx.RecoverFromPanics())
+
+	inj.app = fx.New(
+		options...,
+	)
+
+	if inj.app.Err() != nil {
+		inj.fxLogger.Flush(log.DefaultLogger)
+		report.Log(log.DefaultLogger)
+	}
+
+	inj.run(stopTimeout)
+}
+
+// Options 将已收集的依赖信息及 opts 组装为 fx 的启动选项，但不会创建及启动容器,
+// 该接口一般用于测试等需要自行控制容器生命周期的场景
+func (inj *Injector) Options(opts ...Option) []fx.Option {
+	if inj.fxLogger == nil {
+		inj.fxLogger = NewFxLogger()
+	}
+
 	injectCache := inj.cache.injectCache()
 	injectCache = append(injectCache,
 		fx.Annotate(
@@ -60,6 +98,7 @@ func (inj *Injector) DoIt(opts ...Option) {
 		fx.Provide(
 			injectCache...,
 		),
+		fx.Supply(inj.fxLogger),
 	}
 
 	options = append(options, fx.Invoke(injectEntrance))
@@ -72,18 +111,20 @@ func (inj *Injector) DoIt(opts ...Option) {
 		options = append(options, o.Provide())
 	}
 
-	//options = append(options, fx.RecoverFromPanics())
-
-	inj.app = fx.New(
-		options...,
-	)
-
-	inj.app.Run()
+	return options
 }
 
+// Middle 为自定义中间件的注册信息
 type Middle struct {
 	Middleware middleware.Middleware
-	MidType    string
+	MidType    string          // MidType 为中间件生效的协议，为空时对 HTTP 及 GRPC 均生效
+	Phase      MiddlewarePhase // Phase 为中间件在调用链中的阶段，默认为 PhaseBeforeHandler
+	Priority   int             // Priority 为同一阶段内的优先级，值越小越先执行，相同优先级按注册顺序执行
+	Prefix     []string        // Prefix 为需要匹配的 Operation 前缀
+	Regex      []string        // Regex 为需要匹配的 Operation 正则表达式
+	Operations []string        // Operations 为需要匹配的 Operation 列表, 即 tr.Operation() 的值
+
+	order int
 }
 
 type MiddlewareCollector struct {
@@ -0,0 +1,162 @@
+package injection
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.uber.org/fx/fxevent"
+)
+
+// FxLogger 将 fx 内部的 provide/invoke/lifecycle 事件转换为 kratos 的结构化日志, 使其与业务日志使用相同的输出及前缀字段.
+// 由于日志实例本身也通过依赖注入创建，在调用 SetLogger 之前产生的事件会被缓存，并在设置后统一输出
+type FxLogger struct {
+	lock    sync.Mutex
+	logger  log.Logger
+	level   func() string
+	pending []fxevent.Event
+}
+
+// NewFxLogger 创建 fx 事件日志适配器
+func NewFxLogger() *FxLogger {
+	return &FxLogger{
+		pending: make([]fxevent.Event, 0),
+	}
+}
+
+// SetLogger 设置输出事件的日志实例, level 返回 fx 事件的最低输出级别 (debug/info/warn/error),
+// 每次输出时都会重新获取，因此可以通过配置中心动态调整, level 为 nil 或返回空时默认为 info
+func (l *FxLogger) SetLogger(logger log.Logger, level func() string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.logger = logger
+	l.level = level
+
+	for _, event := range l.pending {
+		l.write(event)
+	}
+	l.pending = nil
+}
+
+// Flush 在尚未设置日志实例时使用 logger 输出已缓存的事件，一般用于启动失败时输出已产生的事件
+func (l *FxLogger) Flush(logger log.Logger) {
+	l.lock.Lock()
+	ready := l.logger != nil
+	l.lock.Unlock()
+
+	if !ready {
+		l.SetLogger(logger, nil)
+	}
+}
+
+// Logger 返回输出事件的日志实例, 未绑定时返回 nil
+func (l *FxLogger) Logger() log.Logger {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.logger
+}
+
+// LogEvent 实现 fxevent.Logger
+func (l *FxLogger) LogEvent(event fxevent.Event) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.logger == nil {
+		l.pending = append(l.pending, event)
+		return
+	}
+
+	l.write(event)
+}
+
+func (l *FxLogger) write(event fxevent.Event) {
+	level, keyvals := fxEventEntry(event)
+	if level < l.minLevel() {
+		return
+	}
+
+	_ = l.logger.Log(level, append([]interface{}{"module", "fx"}, keyvals...)...)
+}
+
+func (l *FxLogger) minLevel() log.Level {
+	if l.level == nil {
+		return log.LevelInfo
+	}
+
+	return log.ParseLevel(strings.TrimSpace(l.level()))
+}
+
+// fxEventEntry 将 fx 事件转换为日志级别及日志字段，构造及注册类的事件为 debug 级别，
+// 生命周期类的事件为 info 级别，包含错误的事件为 error 级别
+func fxEventEntry(event fxevent.Event) (log.Level, []interface{}) {
+	var (
+		level   = log.LevelDebug
+		err     error
+		keyvals []interface{}
+	)
+
+	switch e := event.(type) {
+	case *fxevent.OnStartExecuting:
+		keyvals = []interface{}{"msg", "OnStart hook executing", "callee", e.FunctionName, "caller", e.CallerName}
+	case *fxevent.OnStartExecuted:
+		level, err = log.LevelInfo, e.Err
+		keyvals = []interface{}{"msg", "OnStart hook executed", "callee", e.FunctionName, "caller", e.CallerName, "runtime", e.Runtime.String()}
+	case *fxevent.OnStopExecuting:
+		keyvals = []interface{}{"msg", "OnStop hook executing", "callee", e.FunctionName, "caller", e.CallerName}
+	case *fxevent.OnStopExecuted:
+		level, err = log.LevelInfo, e.Err
+		keyvals = []interface{}{"msg", "OnStop hook executed", "callee", e.FunctionName, "caller", e.CallerName, "runtime", e.Runtime.String()}
+	case *fxevent.Supplied:
+		err = e.Err
+		keyvals = []interface{}{"msg", "supplied", "type", e.TypeName, "fx_module", e.ModuleName}
+	case *fxevent.Provided:
+		err = e.Err
+		keyvals = []interface{}{"msg", "provided", "constructor", e.ConstructorName, "types", e.OutputTypeNames, "fx_module", e.ModuleName}
+	case *fxevent.Replaced:
+		err = e.Err
+		keyvals = []interface{}{"msg", "replaced", "types", e.OutputTypeNames, "fx_module", e.ModuleName}
+	case *fxevent.Decorated:
+		err = e.Err
+		keyvals = []interface{}{"msg", "decorated", "decorator", e.DecoratorName, "types", e.OutputTypeNames, "fx_module", e.ModuleName}
+	case *fxevent.Run:
+		err = e.Err
+		keyvals = []interface{}{"msg", "run", "name", e.Name, "kind", e.Kind, "fx_module", e.ModuleName}
+	case *fxevent.Invoking:
+		keyvals = []interface{}{"msg", "invoking", "function", e.FunctionName, "fx_module", e.ModuleName}
+	case *fxevent.Invoked:
+		err = e.Err
+		keyvals = []interface{}{"msg", "invoked", "function", e.FunctionName, "fx_module", e.ModuleName}
+		if e.Err != nil {
+			keyvals = append(keyvals, "stack", e.Trace)
+		}
+	case *fxevent.Stopping:
+		level = log.LevelInfo
+		keyvals = []interface{}{"msg", "received signal", "signal", strings.ToUpper(e.Signal.String())}
+	case *fxevent.Stopped:
+		level, err = log.LevelInfo, e.Err
+		keyvals = []interface{}{"msg", "stopped"}
+	case *fxevent.RollingBack:
+		level, err = log.LevelError, e.StartErr
+		keyvals = []interface{}{"msg", "start failed, rolling back"}
+	case *fxevent.RolledBack:
+		level, err = log.LevelInfo, e.Err
+		keyvals = []interface{}{"msg", "rolled back"}
+	case *fxevent.Started:
+		level, err = log.LevelInfo, e.Err
+		keyvals = []interface{}{"msg", "started"}
+	case *fxevent.LoggerInitialized:
+		err = e.Err
+		keyvals = []interface{}{"msg", "initialized custom fxevent.Logger", "function", e.ConstructorName}
+	default:
+		keyvals = []interface{}{"msg", "unknown fx event"}
+	}
+
+	if err != nil {
+		level = log.LevelError
+		keyvals = append(keyvals, "err", err)
+	}
+
+	return level, keyvals
+}
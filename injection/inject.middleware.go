@@ -0,0 +1,143 @@
+package injection
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+	"go.uber.org/fx"
+)
+
+// MiddlewarePhase 为自定义中间件在服务端调用链中的阶段, 服务端的调用链为:
+// recovery -> metadata -> tracing -> 监控指标 -> 错误处理 -> 过载保护 -> 超时 -> 请求头 -> [PhaseBeforeAuth] -> 鉴权 -> [PhaseAfterAuth] ->
+// 参数校验 -> 日志 (敏感字段脱敏) -> SQL Action -> [PhaseBeforeHandler] -> 业务处理
+type MiddlewarePhase int
+
+const (
+	PhaseBeforeHandler MiddlewarePhase = iota // PhaseBeforeHandler 在业务处理之前执行，此时已完成鉴权、参数校验及日志记录
+	PhaseBeforeAuth                           // PhaseBeforeAuth 在鉴权之前执行，如限流等无需用户信息的处理
+	PhaseAfterAuth                            // PhaseAfterAuth 在鉴权之后、参数校验之前执行，如权限控制等需要用户信息的处理
+)
+
+const (
+	MiddlewareTypeHTTP = "HTTP"
+	MiddlewareTypeGRPC = "GRPC"
+)
+
+// MiddlewareOption 为注册自定义中间件时的配置项
+type MiddlewareOption func(m *Middle)
+
+// WithPhase 指定中间件在调用链中的阶段
+func WithPhase(phase MiddlewarePhase) MiddlewareOption {
+	return func(m *Middle) {
+		m.Phase = phase
+	}
+}
+
+// WithPriority 指定中间件在同一阶段内的优先级，值越小越先执行
+func WithPriority(priority int) MiddlewareOption {
+	return func(m *Middle) {
+		m.Priority = priority
+	}
+}
+
+// WithTransport 指定中间件只对 HTTP (MiddlewareTypeHTTP) 或 GRPC (MiddlewareTypeGRPC) 生效，未指定时对两者均生效
+func WithTransport(kind string) MiddlewareOption {
+	return func(m *Middle) {
+		m.MidType = kind
+	}
+}
+
+// WithPrefix 指定中间件只对 Operation 前缀为 prefix 的请求生效, 如 /api.user.v1.User/
+func WithPrefix(prefix ...string) MiddlewareOption {
+	return func(m *Middle) {
+		m.Prefix = append(m.Prefix, prefix...)
+	}
+}
+
+// WithRegex 指定中间件只对 Operation 完全匹配 regex 的请求生效
+func WithRegex(regex ...string) MiddlewareOption {
+	return func(m *Middle) {
+		m.Regex = append(m.Regex, regex...)
+	}
+}
+
+// WithOperation 指定中间件只对 Operation 为 operations 的请求生效, 如 /api.user.v1.User/GetUser
+func WithOperation(operations ...string) MiddlewareOption {
+	return func(m *Middle) {
+		m.Operations = append(m.Operations, operations...)
+	}
+}
+
+// InjectMiddleware 注入自定义的服务端中间件, mid 为返回 middleware.Middleware 的构造函数,
+// 可通过 opts 指定中间件的阶段、优先级、生效的协议及需要匹配的 Operation, 如:
+// InjectMiddleware(NewRateLimit, WithPhase(PhaseBeforeAuth), WithPrefix("/api.order.v1.Order/"))
+func (inj *Injector) InjectMiddleware(mid interface{}, opts ...MiddlewareOption) {
+	inj.count += 1
+	midTempTag := fmt.Sprintf(`name:"middleware-%d"`, inj.count)
+
+	middle := Middle{order: inj.count}
+	for _, o := range opts {
+		o(&middle)
+	}
+
+	inj.Inject(fx.Annotate(
+		mid,
+		fx.ResultTags(midTempTag),
+	))
+	inj.Inject(
+		fx.Annotate(
+			func(m middleware.Middleware) Middle {
+				mm := middle
+				mm.Middleware = m
+				return mm
+			},
+			fx.ParamTags(midTempTag),
+			fx.ResultTags(`group:"middleware"`),
+		),
+	)
+}
+
+// Phase 返回对 kind 协议生效且属于 phase 阶段的中间件，返回的中间件已按照优先级及注册顺序排序，
+// 并根据 Operation 的匹配规则进行封装
+func (c *MiddlewareCollector) Phase(kind string, phase MiddlewarePhase) []middleware.Middleware {
+	if c == nil {
+		return nil
+	}
+
+	middles := make([]Middle, 0)
+	for _, m := range c.Middlewares {
+		if m.Phase != phase || (m.MidType != "" && m.MidType != kind) {
+			continue
+		}
+		middles = append(middles, m)
+	}
+
+	sort.SliceStable(middles, func(i, j int) bool {
+		if middles[i].Priority != middles[j].Priority {
+			return middles[i].Priority < middles[j].Priority
+		}
+		return middles[i].order < middles[j].order
+	})
+
+	result := make([]middleware.Middleware, 0, len(middles))
+	for _, m := range middles {
+		result = append(result, m.build())
+	}
+
+	return result
+}
+
+// build 根据 Operation 的匹配规则封装中间件，未指定任何匹配规则时对所有请求生效
+func (m Middle) build() middleware.Middleware {
+	if len(m.Prefix) == 0 && len(m.Regex) == 0 && len(m.Operations) == 0 {
+		return m.Middleware
+	}
+
+	return selector.Server(m.Middleware).
+		Prefix(m.Prefix...).
+		Regex(m.Regex...).
+		Path(m.Operations...).
+		Build()
+}
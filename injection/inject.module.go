@@ -0,0 +1,142 @@
+package injection
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Module 为一组具名的依赖集合，包含了构造函数、启动函数以及其所依赖的其他模块,
+// 通过 Install 安装时会根据模块间的依赖关系决定安装顺序, 如:
+//
+//	injection.NewModule("order").
+//		Require("config", "logger").
+//		Provide(NewOrderRepo, NewOrderService).
+//		Invoke(injection.WithInvoke(StartOrderConsumer))
+type Module struct {
+	name     string
+	requires []string
+	installs []func(inj *Injector)
+}
+
+// NewModule 创建名称为 name 的模块
+func NewModule(name string) *Module {
+	return &Module{
+		name:     name,
+		requires: make([]string, 0),
+		installs: make([]func(inj *Injector), 0),
+	}
+}
+
+// Name 返回模块的名称
+func (m *Module) Name() string {
+	return m.name
+}
+
+// Requires 返回模块依赖的其他模块名称
+func (m *Module) Requires() []string {
+	return m.requires
+}
+
+// As 返回一个名称为 name 的模块副本，用于使用自定义模块替换同名的默认模块
+func (m *Module) As(name string) *Module {
+	return &Module{
+		name:     name,
+		requires: append([]string{}, m.requires...),
+		installs: append([]func(inj *Injector){}, m.installs...),
+	}
+}
+
+// Require 声明当前模块依赖的其他模块，被依赖的模块会先于当前模块安装
+func (m *Module) Require(names ...string) *Module {
+	m.requires = append(m.requires, names...)
+	return m
+}
+
+// Provide 为模块添加构造函数, 具体见 Inject
+func (m *Module) Provide(constructors ...interface{}) *Module {
+	return m.With(func(inj *Injector) {
+		inj.InjectMany(constructors...)
+	})
+}
+
+// Invoke 为模块添加启动时执行的函数, 具体见 Invoke
+func (m *Module) Invoke(opts ...Option) *Module {
+	return m.With(func(inj *Injector) {
+		for _, o := range opts {
+			inj.Invoke(o)
+		}
+	})
+}
+
+// With 为模块添加自定义的安装函数，用于 InjectWithParam / InjectMiddleware 等需要自定义注入方式的场景,
+// 各个 inject 包中的 InjectIns 也可以通过该接口直接作为模块使用
+func (m *Module) With(install func(inj *Injector)) *Module {
+	m.installs = append(m.installs, install)
+	return m
+}
+
+// Install 按照模块间的依赖顺序安装 modules, 已安装过的同名模块会被忽略,
+// 依赖的模块不存在或模块间存在循环依赖时返回错误，此时不会安装任何模块
+func (inj *Injector) Install(modules ...*Module) error {
+	if inj.modules == nil {
+		inj.modules = make(map[string]bool)
+	}
+
+	byName := make(map[string]*Module)
+	for _, m := range modules {
+		if _, exists := byName[m.name]; exists {
+			return fmt.Errorf("[Inject] module %s is duplicated", m.name)
+		}
+		byName[m.name] = m
+	}
+
+	ordered := make([]*Module, 0, len(modules))
+	state := make(map[string]int) // 1: visiting, 2: visited
+
+	var visit func(m *Module, path []string) error
+	visit = func(m *Module, path []string) error {
+		switch state[m.name] {
+		case 1:
+			return fmt.Errorf("[Inject] module dependency cycle %s", strings.Join(append(path, m.name), " -> "))
+		case 2:
+			return nil
+		}
+
+		state[m.name] = 1
+		for _, r := range m.requires {
+			if required, ok := byName[r]; ok {
+				if err := visit(required, append(path, m.name)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if !inj.modules[r] {
+				return fmt.Errorf("[Inject] module %s requires module %s, but it is not installed", m.name, r)
+			}
+		}
+		state[m.name] = 2
+
+		ordered = append(ordered, m)
+		return nil
+	}
+
+	for _, m := range modules {
+		if err := visit(m, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range ordered {
+		if inj.modules[m.name] {
+			continue
+		}
+
+		for _, install := range m.installs {
+			install(inj)
+		}
+		inj.modules[m.name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package injection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// go test -v -count=1 ./injection -test.run=TestInjector_Install
+func TestInjector_Install(t *testing.T) {
+	order := make([]string, 0)
+	module := func(name string, requires ...string) *Module {
+		return NewModule(name).Require(requires...).With(func(_ *Injector) {
+			order = append(order, name)
+		})
+	}
+
+	inj := NewInjector()
+	err := inj.Install(
+		module("server", "config", "logger"),
+		module("logger", "config"),
+		module("config"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"config", "logger", "server"}, order)
+
+	// 已安装的模块可以被后续安装的模块依赖，且不会重复安装
+	err = inj.Install(module("redis", "logger"), module("config"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"config", "logger", "server", "redis"}, order)
+
+	err = inj.Install(module("mongo", "missing"))
+	require.ErrorContains(t, err, "requires module missing")
+
+	err = inj.Install(module("a", "b"), module("b", "a"))
+	require.ErrorContains(t, err, "cycle a -> b -> a")
+}
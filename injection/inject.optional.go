@@ -0,0 +1,41 @@
+package injection
+
+// Optional 表示一个可能不存在的依赖, 如根据配置未启用的组件, 使用者需要通过 Present 或 Get 检查依赖是否存在,
+// 避免直接使用未启用组件产生的 nil 值, 如:
+//
+//	func NewRepo(redis injection.Optional[kit.Redis]) *Repo {
+//		if r, ok := redis.Get(); ok { ... }
+//	}
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// Some 创建一个存在的依赖
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, present: true}
+}
+
+// None 创建一个不存在的依赖
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Get 返回依赖及其是否存在
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// Present 返回依赖是否存在
+func (o Optional[T]) Present() bool {
+	return o.present
+}
+
+// OrElse 在依赖存在时返回依赖，否则返回 other
+func (o Optional[T]) OrElse(other T) T {
+	if o.present {
+		return o.value
+	}
+
+	return other
+}
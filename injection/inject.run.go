@@ -0,0 +1,55 @@
+package injection
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.uber.org/fx"
+)
+
+// StopTimeout 为容器退出时等待所有 OnStop 执行完成的最长时间, fx 默认为 15s,
+// 需要更长的退出时间 (如等待处理中的请求完成) 时可提供该类型的依赖, 小于默认值时不生效
+type StopTimeout time.Duration
+
+type stopTimeoutParam struct {
+	fx.In
+
+	Timeout StopTimeout `optional:"true"`
+}
+
+// run 与 fx.App.Run 行为一致, 但退出时使用依赖中提供的 StopTimeout
+func (inj *Injector) run(stopTimeout *StopTimeout) {
+	startCtx, cancel := context.WithTimeout(context.Background(), inj.app.StartTimeout())
+	defer cancel()
+
+	if err := inj.app.Start(startCtx); err != nil {
+		inj.exit("start application failed", err)
+	}
+
+	signal := <-inj.app.Wait()
+
+	timeout := inj.app.StopTimeout()
+	if d := time.Duration(*stopTimeout); d > timeout {
+		timeout = d
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := inj.app.Stop(stopCtx); err != nil {
+		inj.exit("stop application failed", err)
+	}
+
+	if signal.ExitCode != 0 {
+		os.Exit(signal.ExitCode)
+	}
+}
+
+// exit 输出缓存的 fx 事件及错误后退出, 日志实例未绑定时 (如依赖启动失败) 使用 log.DefaultLogger 输出
+func (inj *Injector) exit(msg string, err error) {
+	inj.fxLogger.Flush(log.DefaultLogger)
+	log.NewHelper(inj.fxLogger.Logger()).Errorw("msg", msg, "err", err)
+	os.Exit(1)
+}
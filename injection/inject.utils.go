@@ -1,12 +1,10 @@
 package injection
 
 import (
-	"fmt"
 	"reflect"
 	"unsafe"
 
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport/http"
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
@@ -83,26 +81,9 @@ func (inj *Injector) Invoke(opt Option) {
 //	)
 //}
 
+// InjectHTTPMiddleware 注入只对 HTTP 生效的自定义中间件，中间件位于 PhaseBeforeHandler 阶段，如需指定阶段或匹配规则请使用 InjectMiddleware
 func (inj *Injector) InjectHTTPMiddleware(mid interface{}) {
-	inj.count += 1
-	midTempTag := fmt.Sprintf(`name:"middleware-%d"`, inj.count)
-
-	inj.Inject(fx.Annotate(
-		mid,
-		fx.ResultTags(midTempTag),
-	))
-	inj.Inject(
-		fx.Annotate(
-			func(m middleware.Middleware) Middle {
-				return Middle{
-					Middleware: m,
-					MidType:    "HTTP",
-				}
-			},
-			fx.ParamTags(midTempTag),
-			fx.ResultTags(`group:"middleware"`),
-		),
-	)
+	inj.InjectMiddleware(mid, WithTransport(MiddlewareTypeHTTP))
 }
 
 // InjectWithParam 提供了注入依赖的时候添加 Param 及 Result 标签的入口，通过标签可以将注入的依赖提供给指定的使用者
@@ -125,26 +106,9 @@ func (inj *Injector) InjectWithParam(anno interface{}, params []string, results
 	inj.Inject(fx.Annotate(anno, annotates...))
 }
 
+// InjectGRPCMiddleware 注入只对 GRPC 生效的自定义中间件，中间件位于 PhaseBeforeHandler 阶段，如需指定阶段或匹配规则请使用 InjectMiddleware
 func (inj *Injector) InjectGRPCMiddleware(mid interface{}) {
-	inj.count += 1
-	midTempTag := fmt.Sprintf(`name:"middleware-%d"`, inj.count)
-
-	inj.Inject(fx.Annotate(
-		mid,
-		fx.ResultTags(midTempTag),
-	))
-	inj.Inject(
-		fx.Annotate(
-			func(m middleware.Middleware) Middle {
-				return Middle{
-					Middleware: m,
-					MidType:    "GRPC",
-				}
-			},
-			fx.ParamTags(midTempTag),
-			fx.ResultTags(`group:"middleware"`),
-		),
-	)
+	inj.InjectMiddleware(mid, WithTransport(MiddlewareTypeGRPC))
 }
 
 // InjectGRPCClient 注入 protocol buffer 生成的 GRPC 或 HTTP 客户端
@@ -0,0 +1,75 @@
+package injectiontest
+
+import (
+	"sort"
+	"sync"
+
+	"go.uber.org/fx/fxevent"
+)
+
+// eventRecorder 记录 fx 在构建依赖图时产生的事件，用于分析哪些依赖从未被使用
+type eventRecorder struct {
+	lock     sync.Mutex
+	provided map[string][]string // provided 记录构造函数及其提供的类型
+	run      map[string]bool     // run 记录已被调用过的构造函数
+	replaced map[string]bool     // replaced 记录已被 Decorate / Replace 替换的类型
+}
+
+func newEventRecorder() *eventRecorder {
+	return &eventRecorder{
+		provided: make(map[string][]string),
+		run:      make(map[string]bool),
+		replaced: make(map[string]bool),
+	}
+}
+
+func (r *eventRecorder) LogEvent(event fxevent.Event) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	switch e := event.(type) {
+	case *fxevent.Provided:
+		r.provided[e.ConstructorName] = e.OutputTypeNames
+	case *fxevent.Run:
+		r.run[e.Name] = true
+	case *fxevent.Decorated:
+		for _, t := range e.OutputTypeNames {
+			r.replaced[t] = true
+		}
+	case *fxevent.Replaced:
+		for _, t := range e.OutputTypeNames {
+			r.replaced[t] = true
+		}
+	}
+}
+
+// unused 返回从未被调用且未被完全替换的构造函数
+func (r *eventRecorder) unused() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	names := make([]string, 0)
+	for name, outputs := range r.provided {
+		if r.run[name] || r.allReplaced(outputs) {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (r *eventRecorder) allReplaced(outputs []string) bool {
+	if len(outputs) == 0 {
+		return false
+	}
+
+	for _, t := range outputs {
+		if !r.replaced[t] {
+			return false
+		}
+	}
+
+	return true
+}
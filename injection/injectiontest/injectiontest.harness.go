@@ -0,0 +1,184 @@
+package injectiontest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+// Harness 为通过 Injector 组装的服务提供测试能力，他只构建依赖图并解析依赖，不会启动任何服务 (不会触发 OnStart),
+// 测试中可以通过 WithFake / WithValue 将任意的依赖替换为假的实现，如将 kit.Redis 替换为内存实现
+type Harness struct {
+	t         testing.TB
+	inj       *injection.Injector
+	options   []fx.Option
+	recorder  *eventRecorder
+	ignoreAll bool
+	ignored   []string
+}
+
+// Option 为 Harness 的配置项
+type Option func(h *Harness)
+
+// New 基于 inj 创建一个测试用的依赖注入容器，inj 中已注入的依赖都会被加入到依赖图中，
+// 可通过 opts 对依赖进行替换或补充
+func New(t testing.TB, inj *injection.Injector, opts ...Option) *Harness {
+	h := &Harness{
+		t:        t,
+		inj:      inj,
+		options:  make([]fx.Option, 0),
+		recorder: newEventRecorder(),
+		ignored:  make([]string, 0),
+	}
+
+	for _, o := range opts {
+		o(h)
+	}
+
+	return h
+}
+
+// WithFake 使用 constructor 的返回值替换依赖图中相同类型的依赖，被替换的依赖的构造函数不会被调用,
+// constructor 可以依赖其他已注入的组件, 如:
+// WithFake(func() kit.Redis { return fakeRedis })
+func WithFake(constructor interface{}) Option {
+	return func(h *Harness) {
+		h.options = append(h.options, fx.Decorate(constructor))
+	}
+}
+
+// WithValue 使用 value 替换依赖图中相同类型的依赖，当需要替换的是接口类型时，需要通过 as 指定接口, 如:
+// WithValue(fakeRepo, new(config.ConfigureWatcherRepo))
+func WithValue(value interface{}, as ...interface{}) Option {
+	return func(h *Harness) {
+		if len(as) == 0 {
+			h.options = append(h.options, fx.Replace(value))
+			return
+		}
+
+		h.options = append(h.options, fx.Replace(fx.Annotate(value, fx.As(as...))))
+	}
+}
+
+// WithProvide 为测试补充 inj 中不存在的依赖
+func WithProvide(constructors ...interface{}) Option {
+	return func(h *Harness) {
+		h.options = append(h.options, fx.Provide(constructors...))
+	}
+}
+
+// IgnoreUnused 忽略未被使用的依赖检查，names 为需要忽略的构造函数名称 (支持前缀匹配),
+// 不传递 names 时忽略所有未被使用的依赖
+func IgnoreUnused(names ...string) Option {
+	return func(h *Harness) {
+		if len(names) == 0 {
+			h.ignoreAll = true
+		}
+		h.ignored = append(h.ignored, names...)
+	}
+}
+
+// Populate 构建依赖图并将解析的依赖填充到 target 中, target 必须是结构体指针,
+// 结构体中所有导出的字段都会被作为依赖进行填充, 如结构体嵌入了 injection.In, 则可以通过 name / group / optional 等标签获取依赖.
+// 依赖缺失或存在未被使用的依赖时会作为测试失败进行报告
+func (h *Harness) Populate(target interface{}) {
+	h.t.Helper()
+
+	populate, err := populateOption(target)
+	if err != nil {
+		h.t.Fatalf("[InjectionTest] %s", err)
+		return
+	}
+
+	options := h.inj.Options()
+	options = append(options, h.options...)
+	options = append(options,
+		populate,
+		fx.WithLogger(func() fxevent.Logger { return h.recorder }),
+	)
+
+	app := fx.New(options...)
+	if err = app.Err(); err != nil {
+		h.t.Fatalf("[InjectionTest] build dependency graph failed with error %s", err)
+		return
+	}
+
+	for _, name := range h.unused() {
+		h.t.Errorf("[InjectionTest] provider %s is never used", name)
+	}
+}
+
+// unused 返回已注册但从未被构造的依赖，通过 WithFake / WithValue 替换的依赖不会被认为未使用
+func (h *Harness) unused() []string {
+	if h.ignoreAll {
+		return nil
+	}
+
+	names := make([]string, 0)
+	for _, name := range h.recorder.unused() {
+		if h.isIgnored(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (h *Harness) isIgnored(name string) bool {
+	// 框架内部的依赖按需使用，不做检查
+	if strings.HasPrefix(name, "go.uber.org/fx") ||
+		strings.Contains(name, "github.com/eden-quan/go-biz-kit/injection.") {
+		return true
+	}
+
+	for _, prefix := range h.ignored {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// populateOption 根据 target 的类型生成填充依赖的选项
+func populateOption(target interface{}) (fx.Option, error) {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("populate target must be a pointer to struct, but got %s", value.Type())
+	}
+
+	elem := value.Elem()
+	if isParamObject(elem.Type()) {
+		return fx.Populate(target), nil
+	}
+
+	fields := make([]interface{}, 0, elem.NumField())
+	for i := 0; i < elem.NumField(); i++ {
+		if !elem.Type().Field(i).IsExported() {
+			continue
+		}
+		fields = append(fields, elem.Field(i).Addr().Interface())
+	}
+
+	return fx.Populate(fields...), nil
+}
+
+// isParamObject 检查 t 是否嵌入了 fx.In
+func isParamObject(t reflect.Type) bool {
+	inType := reflect.TypeOf(injection.In{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type == inType {
+			return true
+		}
+	}
+
+	return false
+}
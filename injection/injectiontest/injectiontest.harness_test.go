@@ -0,0 +1,80 @@
+package injectiontest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type realGreeter struct{}
+
+func (g *realGreeter) Greet() string { return "real" }
+
+type fakeGreeter struct{}
+
+func (g *fakeGreeter) Greet() string { return "fake" }
+
+type service struct {
+	greeter greeter
+}
+
+func newGreeter() greeter {
+	panic("real greeter should be replaced by fake")
+}
+
+func newService(g greeter) *service {
+	return &service{greeter: g}
+}
+
+// go test -v -count=1 ./injection/injectiontest -test.run=TestHarness_Populate
+func TestHarness_Populate(t *testing.T) {
+	inj := injection.NewInjector()
+	inj.InjectMany(newGreeter, newService)
+
+	var target struct {
+		Service *service
+	}
+
+	New(t, &inj, WithFake(func() greeter { return &fakeGreeter{} })).Populate(&target)
+
+	require.NotNil(t, target.Service)
+	require.Equal(t, "fake", target.Service.greeter.Greet())
+}
+
+// go test -v -count=1 ./injection/injectiontest -test.run=TestHarness_WithValue
+func TestHarness_WithValue(t *testing.T) {
+	inj := injection.NewInjector()
+	inj.InjectMany(newGreeter, newService)
+
+	var target struct {
+		injection.In
+		Greeter greeter
+	}
+
+	New(t, &inj, WithValue(&realGreeter{}, new(greeter)), IgnoreUnused()).Populate(&target)
+
+	require.Equal(t, "real", target.Greeter.Greet())
+}
+
+// go test -v -count=1 ./injection/injectiontest -test.run=TestHarness_Unused
+func TestHarness_Unused(t *testing.T) {
+	inj := injection.NewInjector()
+	inj.InjectMany(newService)
+
+	var target struct {
+		Greeter greeter
+	}
+
+	name := "github.com/eden-quan/go-biz-kit/injection/injectiontest.newService()"
+	h := New(t, &inj, WithProvide(func() greeter { return &realGreeter{} }), IgnoreUnused(name))
+	h.Populate(&target)
+
+	require.Contains(t, h.recorder.unused(), name)
+	require.Empty(t, h.unused())
+}
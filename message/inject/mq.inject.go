@@ -13,3 +13,8 @@ func Inject() {
 func InjectIns(inj *injection.Injector) {
 	inj.Inject(message.NewQueueFactory)
 }
+
+// Module 消息队列模块 message，提供 message.QueueFactory
+func Module() *injection.Module {
+	return injection.NewModule("message").Require("config", "logger").With(InjectIns)
+}
@@ -0,0 +1,25 @@
+package message
+
+import (
+	errorutil "github.com/eden-quan/go-biz-kit/error"
+)
+
+// disabledFactory 为未启用消息队列时使用的 QueueFactory, 创建生产者及消费者时都会返回 errorutil.ComponentDisabled 错误
+type disabledFactory struct{}
+
+// NewDisabledQueueFactory 创建未启用的消息队列
+func NewDisabledQueueFactory() QueueFactory {
+	return &disabledFactory{}
+}
+
+func (f *disabledFactory) Producer(_ *TopicConfig) (Producer, error) {
+	return nil, errorutil.ComponentDisabled.ToError("rabbitmq is disabled, please check /middleware/rabbitmq/config")
+}
+
+func (f *disabledFactory) Consumer(_ *TopicConfig) (Consumer, error) {
+	return nil, errorutil.ComponentDisabled.ToError("rabbitmq is disabled, please check /middleware/rabbitmq/config")
+}
+
+func (f *disabledFactory) Disabled() bool {
+	return true
+}
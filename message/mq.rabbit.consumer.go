@@ -10,6 +10,7 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/payload"
 )
 
 type consumer struct {
@@ -91,6 +92,7 @@ func (c *consumer) startConsumer() {
 			if msg.Body == nil && msg.Exchange == "" && curChan.IsClosed() {
 				return
 			}
+			payload.Message(c.logger, "consume", msg.Exchange, msg.RoutingKey, msg.Body)
 			c.msgChan <- &Message{
 				MessageId:       msg.MessageId,
 				Queue:           c.queue.Name,
@@ -124,6 +126,10 @@ func (c *consumer) watchChan() error {
 					continue
 				}
 
+				if c.conn.Closed() {
+					return
+				}
+
 				conErr := c.reconnect() // create new consumer
 				if conErr != nil {
 					time.Sleep(10 * time.Second)
@@ -132,6 +138,8 @@ func (c *consumer) watchChan() error {
 				receiver = make(chan *amqp.Error)
 				c.channel.NotifyClose(receiver)
 
+			case <-c.conn.Done():
+				return
 			case <-time.After(time.Second * 30):
 				log.NewHelper(c.logger).Debugf(
 					"consumer (queue(%s):consumer(%s) channel monitor alive...",
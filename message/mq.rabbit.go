@@ -17,6 +17,7 @@ type factoryImpl struct {
 	local    *config.LocalConfigure // 本地配置
 	conn     *amqp.Connection       // amqp 的连接
 	exitChan chan bool              // 退出信号
+	exitOnce sync.Once              // 确保退出信号只发送一次
 	lock     sync.Mutex             // 获取及创建连接的同步锁
 }
 
@@ -28,12 +29,27 @@ func (c *connProxy) Get() *amqp.Connection {
 	return c.f.GetConn()
 }
 
+// Done 返回管理器的退出信号, 关闭后 Channel 不再自动重连
+func (c *connProxy) Done() <-chan bool {
+	return c.f.exitChan
+}
+
+// Closed 检查管理器是否已关闭
+func (c *connProxy) Closed() bool {
+	select {
+	case <-c.f.exitChan:
+		return true
+	default:
+		return false
+	}
+}
+
 // NewQueueFactory 创建一个消息队列的管理器，用于创建消费者及生产者,
-// logger 提供日志记录能力， conf 为配置中心
+// logger 提供日志记录能力， conf 为配置中心, 未启用消息队列时返回的管理器所有操作都会返回 errorutil.ComponentDisabled 错误
 func NewQueueFactory(logger log.Logger, conf *def.Configuration, local *config.LocalConfigure) (QueueFactory, error) {
 
 	if !conf.MessageQueue.Enable {
-		return nil, nil
+		return NewDisabledQueueFactory(), nil
 	}
 
 	f := &factoryImpl{
@@ -81,6 +97,10 @@ func (f *factoryImpl) watchConn() error {
 					continue
 				}
 
+				if (&connProxy{f: f}).Closed() {
+					return
+				}
+
 				conErr := f.reconnect()
 
 				if conErr != nil {
@@ -89,6 +109,8 @@ func (f *factoryImpl) watchConn() error {
 
 				receiver = make(chan *amqp.Error)
 				f.conn.NotifyClose(receiver)
+			case <-f.exitChan:
+				return
 			case <-time.After(time.Second * 30):
 				log.NewHelper(f.logger).Debug("rabbit connection monitor alive...")
 			}
@@ -112,3 +134,20 @@ func (f *factoryImpl) GetConn() *amqp.Connection {
 
 	return f.conn
 }
+
+// Close 停止自动重连并关闭连接, 连接关闭时其上的所有消费者及生产者的 Channel 都会被关闭,
+// 未确认的消息会由 RabbitMQ 重新投递给其他消费者
+func (f *factoryImpl) Close() error {
+	f.exitOnce.Do(func() {
+		close(f.exitChan)
+	})
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.conn == nil || f.conn.IsClosed() {
+		return nil
+	}
+
+	return f.conn.Close()
+}
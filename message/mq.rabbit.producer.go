@@ -10,6 +10,7 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/payload"
 )
 
 // Producer 为已与指定 Topic 绑定的生产者, Push 会根据 Topic 的配置来触发不同的行为，
@@ -54,6 +55,10 @@ func (p *producer) watchChan() error {
 					continue
 				}
 
+				if p.conn.Closed() {
+					return
+				}
+
 				conErr := p.reconnect()
 				if conErr != nil {
 					time.Sleep(10 * time.Second)
@@ -61,6 +66,8 @@ func (p *producer) watchChan() error {
 
 				receiver = make(chan *amqp.Error)
 				p.channel.NotifyClose(receiver)
+			case <-p.conn.Done():
+				return
 			case <-time.After(time.Second * 30):
 				log.NewHelper(p.logger).Debugf(
 					"producer (exchange(%s):queue(%s) channel monitor alive...",
@@ -124,5 +131,8 @@ func (p *producer) Push(context context.Context, data interface{}, option ...Pus
 	}
 
 	err = p.channel.PublishWithContext(context, opt.exchangeName, opt.routingKey, opt.mandatory, opt.immediately, opt.msg)
+	if err == nil {
+		payload.Message(p.logger, "publish", opt.exchangeName, opt.routingKey, body)
+	}
 	return
 }
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+	errorutil "github.com/eden-quan/go-biz-kit/error"
+)
+
+// DefaultPath 监控指标默认的 HTTP 路径
+const DefaultPath = "/metrics"
+
+// 指标注册在 Prometheus 的默认注册表中, 默认注册表同时包含了 Go 运行时及进程的指标
+var (
+	serverRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "biz_kit",
+		Subsystem: "server",
+		Name:      "requests_total",
+		Help:      "The total number of requests handled by the server.",
+	}, []string{"kind", "operation", "code", "biz_code"})
+
+	serverDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "biz_kit",
+		Subsystem: "server",
+		Name:      "request_duration_seconds",
+		Help:      "The latency of requests handled by the server.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind", "operation", "code", "biz_code"})
+
+	serverInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "biz_kit",
+		Subsystem: "server",
+		Name:      "requests_in_flight",
+		Help:      "The number of requests currently handled by the server.",
+	}, []string{"kind", "operation"})
+
+	clientRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "biz_kit",
+		Subsystem: "client",
+		Name:      "requests_total",
+		Help:      "The total number of requests sent by the client.",
+	}, []string{"kind", "operation", "code", "biz_code"})
+
+	clientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "biz_kit",
+		Subsystem: "client",
+		Name:      "request_duration_seconds",
+		Help:      "The latency of requests sent by the client.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind", "operation", "code", "biz_code"})
+
+	clientInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "biz_kit",
+		Subsystem: "client",
+		Name:      "requests_in_flight",
+		Help:      "The number of requests currently sent by the client and waiting for reply.",
+	}, []string{"kind", "operation"})
+)
+
+// Server 记录服务端的请求数、耗时及处理中的请求数, 标签包括 kind (http/grpc)、operation、code (HTTP 状态码) 及 biz_code (ErrorsCode 中的 BizCode)
+func Server() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			return observe(ctx, req, handler, tr, serverRequests, serverDuration, serverInFlight)
+		}
+	}
+}
+
+// Client 记录客户端的请求数、耗时及等待响应的请求数, 标签与 Server 相同
+func Client() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			return observe(ctx, req, handler, tr, clientRequests, clientDuration, clientInFlight)
+		}
+	}
+}
+
+func observe(
+	ctx context.Context,
+	req interface{},
+	handler middleware.Handler,
+	tr transport.Transporter,
+	requests *prometheus.CounterVec,
+	duration *prometheus.HistogramVec,
+	inFlight *prometheus.GaugeVec,
+) (interface{}, error) {
+	kind, operation := tr.Kind().String(), tr.Operation()
+
+	gauge := inFlight.WithLabelValues(kind, operation)
+	gauge.Inc()
+	defer gauge.Dec()
+
+	start := time.Now()
+	reply, err := handler(ctx, req)
+
+	code, bizCode := codes(err)
+	requests.WithLabelValues(kind, operation, code, bizCode).Inc()
+	duration.WithLabelValues(kind, operation, code, bizCode).Observe(time.Since(start).Seconds())
+
+	return reply, err
+}
+
+// codes 返回错误对应的 HTTP 状态码及业务错误码, 成功时为 200 及 0,
+// 错误信息已写入响应的 Result 中时 (见 errorutil.ErrorResultMiddleware) 仍然使用原始的错误
+func codes(err error) (string, string) {
+	if err == nil {
+		return "200", "0"
+	}
+
+	if t, ok := errorutil.IsTruncateToEmptyError(err); ok {
+		err = t.IsTruncateToEmpty()
+	}
+
+	e := errors.FromError(err)
+	if e == nil {
+		return "200", "0"
+	}
+
+	return strconv.Itoa(int(e.Code)), strconv.Itoa(errorutil.BizCode(err))
+}
+
+// Handler 返回暴露监控指标的 http.Handler, conf 返回当前的监控配置, 未启用时返回 404
+func Handler(conf func() *def.Metrics) http.Handler {
+	h := promhttp.Handler()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !conf().GetEnable() {
+			http.NotFound(w, r)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Path 返回监控指标的 HTTP 路径
+func Path(conf *def.Metrics) string {
+	if conf.GetPath() == "" {
+		return DefaultPath
+	}
+
+	return conf.GetPath()
+}
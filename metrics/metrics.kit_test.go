@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/stretchr/testify/require"
+
+	errorutil "github.com/eden-quan/go-biz-kit/error"
+)
+
+// go test -v -count=1 ./metrics -test.run=TestCodes
+func TestCodes(t *testing.T) {
+	code, bizCode := codes(nil)
+	require.Equal(t, "200", code)
+	require.Equal(t, "0", bizCode)
+
+	err := errorutil.NewErrorCode(503, 10000003, "component disabled").ToError("redis disabled")
+	code, bizCode = codes(err)
+	require.Equal(t, "503", code)
+	require.Equal(t, "10000003", bizCode)
+
+	code, bizCode = codes(errors.NotFound("NOT_FOUND", "not found"))
+	require.Equal(t, "404", code)
+	require.Equal(t, "0", bizCode)
+}
@@ -0,0 +1,335 @@
+package middlewareutil
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	stdjson "encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	kit "github.com/eden-quan/go-biz-kit"
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	contextutil "github.com/eden-quan/go-biz-kit/context"
+	errorutil "github.com/eden-quan/go-biz-kit/error"
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+const (
+	// IdempotencyKeyHeader 为标识重复请求的请求头
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotencyKeyMetadata 为标识重复请求的元数据, 未设置请求头时使用
+	IdempotencyKeyMetadata = "x-md-idempotency-key"
+
+	// MaxIdempotencyKeyLength 为幂等 key 的最大长度, 超过时返回 INVALID_PARAMETER 错误
+	MaxIdempotencyKeyLength = 128
+
+	defaultIdempotencyTTL  = time.Hour * 24
+	defaultIdempotencyLock = time.Minute
+)
+
+// releaseLockScript 只在锁仍属于当前请求时释放锁
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// IdempotencyParam 为创建幂等中间件所需的依赖, 未注入 Redis 时不做幂等处理
+type IdempotencyParam struct {
+	fx.In
+
+	Configuration *def.Configuration
+	Local         *config.LocalConfigure
+	Logger        log.Logger
+	Redis         injection.Optional[kit.Redis] `optional:"true"`
+}
+
+// idempotentRecord 为保存在 Redis 中的处理结果, 响应使用 proto 序列化, 错误保存为 common.Result
+type idempotentRecord struct {
+	RequestHash string          `json:"request_hash,omitempty"` // 首次请求内容的摘要, 相同 key 的请求内容不一致时拒绝
+	ReplyType   string          `json:"reply_type,omitempty"`
+	Reply       []byte          `json:"reply,omitempty"`
+	HttpCode    int             `json:"http_code,omitempty"`
+	Error       *errorv1.Result `json:"error,omitempty"`
+}
+
+// NewIdempotency 创建幂等中间件, 幂等配置通过配置中心 /middleware/idempotency/config 配置, 并在每次请求时读取,
+// 请求携带 Idempotency-Key 时在 Redis 中加锁处理, 处理结果 (响应或错误) 保存 ttl 时间, 期间相同 key 的请求直接返回保存的结果,
+// 相同 key 的请求正在处理时返回 CONFLICT 错误, 相同 key 的请求内容与首次请求不一致时返回 INVALID_PARAMETER 错误,
+// key 按接口及用户隔离, 服务端错误 (5xx) 不保存, 以便调用方重试
+func NewIdempotency(p IdempotencyParam) middleware.Middleware {
+	helper := log.NewHelper(log.With(p.Logger, "module", "idempotency"))
+	warning := sync.Once{}
+
+	var db redis.UniversalClient
+	if rds, ok := p.Redis.Get(); ok && !kit.IsDisabled(rds) {
+		db = rds.Get()
+	}
+	prefix := "idempotency:" + p.Local.APP.Name + ":"
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			conf := &p.Configuration.Idempotency
+			if !conf.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok || !matchOperations(conf.GetOperations(), tr.Operation()) {
+				return handler(ctx, req)
+			}
+
+			key := idempotencyKey(ctx, tr)
+			if key == "" {
+				return handler(ctx, req)
+			}
+
+			if len(key) > MaxIdempotencyKeyLength {
+				return nil, errorv1.ErrorInvalidParameter("idempotency key is longer than %d", MaxIdempotencyKeyLength)
+			}
+
+			if db == nil {
+				warning.Do(func() {
+					helper.Warnw("msg", "redis is not available, idempotency is disabled", "operation", tr.Operation())
+				})
+				return handler(ctx, req)
+			}
+
+			digest, digestErr := requestDigest(req)
+			if digestErr != nil {
+				helper.Errorw("msg", "digest request failed, idempotency is skipped", "operation", tr.Operation(), "err", digestErr)
+				return handler(ctx, req)
+			}
+
+			userID, _ := contextutil.GetUserID(ctx)
+			recordKey := prefix + tr.Operation() + ":" + userID + ":" + key
+			lockKey := recordKey + ":lock"
+
+			if reply, err, ok := lookupRecord(ctx, db, recordKey, digest, key); ok {
+				return reply, err
+			}
+
+			lockTimeout := defaultIdempotencyLock
+			if conf.GetLockTimeout() != nil {
+				lockTimeout = conf.GetLockTimeout().AsDuration()
+			}
+
+			token := newLockToken()
+			locked, lockErr := db.SetNX(ctx, lockKey, token, lockTimeout).Result()
+			if lockErr != nil {
+				// Redis 异常时放行请求，避免幂等组件影响业务的可用性
+				helper.Errorw("msg", "lock idempotency key failed", "operation", tr.Operation(), "err", lockErr)
+				return handler(ctx, req)
+			}
+			if !locked {
+				return nil, errorv1.ErrorConflict("request with idempotency key %s is in progress", key)
+			}
+			defer func() {
+				// 使用独立的 context, 避免请求超时后无法释放锁
+				if releaseErr := releaseLockScript.Run(context.Background(), db, []string{lockKey}, token).Err(); releaseErr != nil {
+					helper.Errorw("msg", "release idempotency lock failed", "key", lockKey, "err", releaseErr)
+				}
+			}()
+
+			// 加锁前结果可能已被其他请求保存
+			if reply, err, ok := lookupRecord(ctx, db, recordKey, digest, key); ok {
+				return reply, err
+			}
+
+			reply, err = handler(ctx, req)
+
+			record, storable := newRecord(reply, err)
+			if !storable {
+				return reply, err
+			}
+			record.RequestHash = digest
+
+			ttl := defaultIdempotencyTTL
+			if conf.GetTtl() != nil {
+				ttl = conf.GetTtl().AsDuration()
+			}
+
+			data, marshalErr := stdjson.Marshal(record)
+			if marshalErr == nil {
+				marshalErr = db.Set(context.Background(), recordKey, data, ttl).Err()
+			}
+			if marshalErr != nil {
+				helper.Errorw("msg", "save idempotency record failed", "key", recordKey, "err", marshalErr)
+			}
+
+			return reply, err
+		}
+	}
+}
+
+// matchOperations 判断接口是否匹配 operations 中的任一规则, operations 为空时不匹配任何接口
+func matchOperations(operations []string, operation string) bool {
+	for _, pattern := range operations {
+		if pattern != "" && matchOperation(pattern, operation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// idempotencyKey 从请求头或元数据中获取幂等 key
+func idempotencyKey(ctx context.Context, tr transport.Transporter) string {
+	if key := tr.RequestHeader().Get(IdempotencyKeyHeader); key != "" {
+		return key
+	}
+
+	if md, ok := metadata.FromServerContext(ctx); ok {
+		return md.Get(IdempotencyKeyMetadata)
+	}
+
+	return ""
+}
+
+func newLockToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
+
+// requestDigest 返回请求内容的摘要, proto 消息使用确定性的序列化, 其他请求使用 JSON 序列化
+func requestDigest(req interface{}) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if msg, ok := req.(proto.Message); ok {
+		data, err = proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		data = append([]byte(msg.ProtoReflect().Descriptor().FullName()+":"), data...)
+	} else {
+		data, err = stdjson.Marshal(req)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// lookupRecord 返回保存的处理结果, 请求内容与首次请求不一致时返回 INVALID_PARAMETER 错误, 结果不存在或无法还原时返回 false
+func lookupRecord(ctx context.Context, db redis.UniversalClient, recordKey string, digest string, key string) (interface{}, error, bool) {
+	record, ok := loadRecord(ctx, db, recordKey)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if record.RequestHash != digest {
+		return nil, errorv1.ErrorInvalidParameter("idempotency key %s is already used by a different request", key), true
+	}
+
+	return record.replay()
+}
+
+// loadRecord 读取保存的处理结果, 不存在或无法解析时返回 false
+func loadRecord(ctx context.Context, db redis.UniversalClient, key string) (*idempotentRecord, bool) {
+	data, err := db.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	record := &idempotentRecord{}
+	if err := stdjson.Unmarshal(data, record); err != nil {
+		return nil, false
+	}
+
+	return record, true
+}
+
+// replay 还原保存的响应及错误, 响应类型未注册或无法反序列化时返回 false
+func (record *idempotentRecord) replay() (interface{}, error, bool) {
+	var reply interface{}
+	if record.ReplyType != "" {
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(record.ReplyType))
+		if err != nil {
+			return nil, nil, false
+		}
+
+		msg := mt.New().Interface()
+		if err := proto.Unmarshal(record.Reply, msg); err != nil {
+			return nil, nil, false
+		}
+		reply = msg
+	}
+
+	if record.Error == nil {
+		return reply, nil, true
+	}
+
+	// 使用业务错误码重新生成错误, 由 ErrorResultMiddleware 及 ErrorEncoder 转换为与首次请求相同的 Result
+	bizCode := int(record.Error.GetCode())
+	replayErr := errorutil.NewErrorCode(record.HttpCode, bizCode, record.Error.GetReason()).ToError("%s", record.Error.GetMessage())
+	if len(record.Error.GetMetaData()) != 0 {
+		replayErr = errors.FromError(replayErr).WithMetadata(record.Error.GetMetaData())
+	}
+
+	return reply, replayErr, true
+}
+
+// newRecord 根据处理结果生成保存的记录, 服务端错误及无法序列化的响应不保存
+func newRecord(reply interface{}, err error) (*idempotentRecord, bool) {
+	record := &idempotentRecord{}
+
+	if reply != nil {
+		msg, ok := reply.(proto.Message)
+		if !ok {
+			return nil, false
+		}
+
+		data, marshalErr := proto.Marshal(msg)
+		if marshalErr != nil {
+			return nil, false
+		}
+		record.ReplyType = string(msg.ProtoReflect().Descriptor().FullName())
+		record.Reply = data
+	}
+
+	if err == nil {
+		return record, true
+	}
+
+	cause := err
+	if t, ok := errorutil.IsTruncateToEmptyError(err); ok {
+		cause = t.IsTruncateToEmpty()
+	}
+
+	e := errors.FromError(cause)
+	if e == nil {
+		return record, true
+	}
+	if e.Code >= 500 {
+		return nil, false
+	}
+
+	record.HttpCode = int(e.Code)
+	record.Error = &errorv1.Result{
+		Code:     int32(errorutil.BizCode(cause)),
+		Reason:   e.Reason,
+		Message:  e.Message,
+		MetaData: e.Metadata,
+	}
+
+	return record, true
+}
@@ -0,0 +1,125 @@
+package middlewareutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	errorutil "github.com/eden-quan/go-biz-kit/error"
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+// go test -v -count=1 ./middleware -test.run=TestIdempotentRecord
+func TestIdempotentRecord(t *testing.T) {
+	record, ok := newRecord(wrapperspb.String("done"), nil)
+	require.True(t, ok)
+	reply, err, ok := record.replay()
+	require.True(t, ok)
+	require.NoError(t, err)
+	require.Equal(t, "done", reply.(*wrapperspb.StringValue).GetValue())
+
+	record, ok = newRecord(nil, errorutil.NewErrorCode(409, 10086, "ORDER_EXISTS").ToError("order %s exists", "A1"))
+	require.True(t, ok)
+	require.Equal(t, 409, record.HttpCode)
+	require.Equal(t, int32(10086), record.Error.GetCode())
+	require.Equal(t, "ORDER_EXISTS", record.Error.GetReason())
+	_, err, ok = record.replay()
+	require.True(t, ok)
+	require.Equal(t, 409, int(errors.FromError(err).Code))
+	require.Equal(t, "ORDER_EXISTS", errors.FromError(err).Reason)
+	require.Equal(t, 10086, errorutil.BizCode(err))
+
+	_, ok = newRecord(nil, errorv1.ErrorFatal("database is down"))
+	require.False(t, ok)
+
+	_, ok = newRecord(&struct{}{}, nil)
+	require.False(t, ok)
+
+	require.False(t, matchOperations(nil, "/api.Order/Create"))
+	require.True(t, matchOperations([]string{"/api.Order/*"}, "/api.Order/Create"))
+}
+
+// go test -v -count=1 ./middleware -test.run=TestIdempotency
+func TestIdempotency(t *testing.T) {
+	fake, rds := newFakeRedis()
+	configuration := &def.Configuration{}
+	configuration.Idempotency.Enable = true
+	configuration.Idempotency.Operations = []string{"/api.Order/*"}
+	local := &config.LocalConfigure{}
+	local.APP.Name = "order-service"
+
+	calls := 0
+	var reply interface{}
+	var replyErr error
+	handler := NewIdempotency(IdempotencyParam{
+		Configuration: configuration,
+		Local:         local,
+		Logger:        log.DefaultLogger,
+		Redis:         injection.Some(rds),
+	})(func(context.Context, interface{}) (interface{}, error) {
+		calls += 1
+		return reply, replyErr
+	})
+
+	call := func(key string, req string) (interface{}, error) {
+		ctx, _ := newTestContext("/api.Order/Create", "", IdempotencyKeyHeader, key)
+		return handler(ctx, wrapperspb.String(req))
+	}
+
+	// 首次请求执行并保存结果, 相同 key 的请求直接返回保存的结果
+	reply = wrapperspb.String("order-1")
+	got, err := call("k1", "create order")
+	require.NoError(t, err)
+	require.Equal(t, "order-1", got.(*wrapperspb.StringValue).GetValue())
+
+	reply = wrapperspb.String("order-2")
+	got, err = call("k1", "create order")
+	require.NoError(t, err)
+	require.Equal(t, "order-1", got.(*wrapperspb.StringValue).GetValue())
+	require.Equal(t, 1, calls)
+
+	// 处理完成后释放锁
+	require.NotContains(t, fake.values, "idempotency:order-service:/api.Order/Create::k1:lock")
+
+	// 相同 key 的请求内容不一致时拒绝
+	_, err = call("k1", "create another order")
+	require.Equal(t, "INVALID_PARAMETER", errors.Reason(err))
+	require.Equal(t, 1, calls)
+
+	// 相同 key 的请求正在处理时返回 CONFLICT
+	fake.values["idempotency:order-service:/api.Order/Create::k2:lock"] = "other"
+	_, err = call("k2", "create order")
+	require.Equal(t, 409, int(errors.FromError(err).Code))
+	require.Equal(t, 1, calls)
+	delete(fake.values, "idempotency:order-service:/api.Order/Create::k2:lock")
+
+	// 业务错误同样会被保存及重放
+	reply, replyErr = nil, errorutil.NewErrorCode(409, 10086, "ORDER_EXISTS").ToError("order exists")
+	_, err = call("k2", "create order")
+	require.Equal(t, "ORDER_EXISTS", errors.Reason(err))
+	_, err = call("k2", "create order")
+	require.Equal(t, "ORDER_EXISTS", errors.Reason(err))
+	require.Equal(t, 10086, errorutil.BizCode(err))
+	require.Equal(t, 2, calls)
+
+	// 服务端错误不保存, 调用方可以重试
+	replyErr = errorv1.ErrorFatal("database is down")
+	_, _ = call("k3", "create order")
+	_, _ = call("k3", "create order")
+	require.Equal(t, 4, calls)
+
+	// key 过长时拒绝, 未携带 key 时不做幂等处理
+	_, err = call(strings.Repeat("k", MaxIdempotencyKeyLength+1), "create order")
+	require.Equal(t, "INVALID_PARAMETER", errors.Reason(err))
+	_, _ = call("", "create order")
+	_, _ = call("", "create order")
+	require.Equal(t, 6, calls)
+}
@@ -0,0 +1,190 @@
+package middlewareutil
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	authpkg "github.com/eden-quan/go-kratos-pkg/auth"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v4"
+	"go.uber.org/fx"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	contextutil "github.com/eden-quan/go-biz-kit/context"
+)
+
+const bearerPrefix = "Bearer "
+
+var (
+	hmacMethods   = []string{"HS256", "HS384", "HS512"}
+	rsaMethods    = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+	ecdsaMethods  = []string{"ES256", "ES384", "ES512"}
+	ed25519Method = []string{"EdDSA"}
+)
+
+// JWTParam 为创建 JWT 鉴权中间件所需的依赖
+type JWTParam struct {
+	fx.In
+
+	Configuration *def.Configuration
+	Logger        log.Logger
+}
+
+// jwtClaims 为令牌中支持的字段, uid 为空时使用 sub 作为用户 ID
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	UserID string   `json:"uid,omitempty"`
+	Tenant string   `json:"tenant,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+	Perms  []string `json:"permissions,omitempty"`
+}
+
+// NewJWTAuth 创建 JWT 鉴权中间件, 鉴权配置通过配置中心 /middleware/jwt/config 配置, 并在每次请求时读取,
+// 令牌通过 Authorization 请求头传递 (Bearer 前缀可选), 默认使用 /basic/secret 中的 jwt_encrypt.key 校验 HMAC 签名,
+// 校验通过后用户信息可通过 contextutil.GetClaims / GetUserID / GetTenant / GetRoles 获取,
+// public_operations 中的接口无需鉴权, 校验失败时返回 UNAUTHORIZED 错误
+func NewJWTAuth(p JWTParam) middleware.Middleware {
+	helper := log.NewHelper(log.With(p.Logger, "module", "jwt"))
+	keys := &jwtKeyCache{}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			conf := &p.Configuration.JWT
+			if !conf.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			for _, operation := range conf.GetPublicOperations() {
+				if operation != "" && matchOperation(operation, tr.Operation()) {
+					return handler(ctx, req)
+				}
+			}
+
+			token := strings.TrimSpace(tr.RequestHeader().Get(authpkg.AuthorizationKey))
+			if len(token) > len(bearerPrefix) && strings.EqualFold(token[:len(bearerPrefix)], bearerPrefix) {
+				token = strings.TrimSpace(token[len(bearerPrefix):])
+			}
+			if token == "" {
+				return nil, errorv1.ErrorUnauthorized("authorization token is required")
+			}
+
+			claims, verifyErr := verifyJWT(token, conf, p.Configuration.Secret.GetJwtEncrypt().GetKey(), keys, time.Now())
+			if verifyErr != nil {
+				helper.Debugw("msg", "jwt verify failed", "operation", tr.Operation(), "err", verifyErr)
+				return nil, errorv1.ErrorUnauthorized("invalid authorization token: %s", verifyErr)
+			}
+
+			return handler(contextutil.WithClaims(ctx, claims), req)
+		}
+	}
+}
+
+// verifyJWT 校验令牌的签名、exp、nbf、aud 及 iss, 返回令牌中的用户信息
+func verifyJWT(token string, conf *def.JWT, secret string, keys *jwtKeyCache, now time.Time) (*contextutil.Claims, error) {
+	key, methods, err := keys.get(conf, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// exp 等时间字段需要支持时钟偏差, 因此跳过默认的校验, 在解析后自行校验
+	parser := jwt.NewParser(jwt.WithValidMethods(methods), jwt.WithoutClaimsValidation())
+	claims := &jwtClaims{}
+	if _, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) { return key, nil }); err != nil {
+		return nil, err
+	}
+
+	leeway := conf.GetLeeway().AsDuration()
+	if !claims.VerifyExpiresAt(now.Add(-leeway), true) {
+		return nil, errors.New("token is expired or exp is missing")
+	}
+	if !claims.VerifyNotBefore(now.Add(leeway), false) {
+		return nil, errors.New("token is not valid yet")
+	}
+	if conf.GetIssuer() != "" && !claims.VerifyIssuer(conf.GetIssuer(), true) {
+		return nil, errors.New("token issuer is not allowed")
+	}
+	if audiences := conf.GetAudiences(); len(audiences) != 0 {
+		allowed := false
+		for _, aud := range audiences {
+			if claims.VerifyAudience(aud, true) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, errors.New("token audience is not allowed")
+		}
+	}
+
+	result := &contextutil.Claims{
+		UserID:      claims.UserID,
+		Tenant:      claims.Tenant,
+		Roles:       claims.Roles,
+		Permissions: claims.Perms,
+		Issuer:      claims.Issuer,
+		Audience:    claims.Audience,
+	}
+	if result.UserID == "" {
+		result.UserID = claims.Subject
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return result, nil
+}
+
+// jwtKeyCache 缓存解析后的公钥, 公钥变化时重新解析
+type jwtKeyCache struct {
+	lock    sync.Mutex
+	pem     string
+	key     crypto.PublicKey
+	methods []string
+}
+
+// get 返回校验签名使用的密钥及允许的签名算法, 配置了公钥时只接受非对称签名
+func (c *jwtKeyCache) get(conf *def.JWT, secret string) (interface{}, []string, error) {
+	pem := conf.GetPublicKey()
+	if pem == "" {
+		if conf.GetSecret() != "" {
+			secret = conf.GetSecret()
+		}
+		if secret == "" {
+			return nil, nil, errors.New("jwt key is not configured")
+		}
+
+		return []byte(secret), hmacMethods, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.pem == pem {
+		return c.key, c.methods, nil
+	}
+
+	if key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(pem)); err == nil {
+		c.key, c.methods = key, rsaMethods
+	} else if key, err := jwt.ParseECPublicKeyFromPEM([]byte(pem)); err == nil {
+		c.key, c.methods = key, ecdsaMethods
+	} else if key, err := jwt.ParseEdPublicKeyFromPEM([]byte(pem)); err == nil {
+		c.key, c.methods = key, ed25519Method
+	} else {
+		return nil, nil, errors.New("jwt public key is not a valid RSA, ECDSA or Ed25519 PEM")
+	}
+	c.pem = pem
+
+	return c.key, c.methods, nil
+}
@@ -0,0 +1,58 @@
+package middlewareutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// go test -v -count=1 ./middleware -test.run=TestVerifyJWT
+func TestVerifyJWT(t *testing.T) {
+	now := time.Now()
+	sign := func(claims jwtClaims, method jwt.SigningMethod) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return token
+	}
+	valid := jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "u-1",
+			Audience:  jwt.ClaimStrings{"web"},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		Tenant: "t-1",
+		Roles:  []string{"admin"},
+	}
+
+	conf := &def.JWT{Enable: true, Audiences: []string{"app", "web"}}
+	claims, err := verifyJWT(sign(valid, jwt.SigningMethodHS256), conf, "secret", &jwtKeyCache{}, now)
+	require.NoError(t, err)
+	require.Equal(t, "u-1", claims.UserID)
+	require.Equal(t, "t-1", claims.Tenant)
+	require.Equal(t, []string{"admin"}, claims.Roles)
+
+	_, err = verifyJWT(sign(valid, jwt.SigningMethodHS256), conf, "other", &jwtKeyCache{}, now)
+	require.Error(t, err)
+
+	_, err = verifyJWT(sign(valid, jwt.SigningMethodHS256), &def.JWT{Audiences: []string{"app"}}, "secret", &jwtKeyCache{}, now)
+	require.Error(t, err)
+
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Second * 10))
+	_, err = verifyJWT(sign(expired, jwt.SigningMethodHS256), conf, "secret", &jwtKeyCache{}, now)
+	require.Error(t, err)
+
+	conf.Leeway = durationpb.New(time.Minute)
+	_, err = verifyJWT(sign(expired, jwt.SigningMethodHS256), conf, "secret", &jwtKeyCache{}, now)
+	require.NoError(t, err)
+
+	notBefore := valid
+	notBefore.NotBefore = jwt.NewNumericDate(now.Add(time.Hour))
+	_, err = verifyJWT(sign(notBefore, jwt.SigningMethodHS256), conf, "secret", &jwtKeyCache{}, now)
+	require.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package middlewareutil
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	contextutil "github.com/eden-quan/go-biz-kit/context"
+)
+
+// PolicyParam 为创建权限校验中间件所需的依赖
+type PolicyParam struct {
+	fx.In
+
+	Configuration *def.Configuration
+	Logger        log.Logger
+}
+
+// NewPolicy 创建权限校验中间件, 策略以 YAML 格式通过配置中心 /middleware/policy/config 配置, 并在每次请求时读取,
+// 根据 tr.Operation() 匹配规则, 校验 contextutil 中用户的角色、权限及请求字段, 需要在 NewJWTAuth 及 NewDecrypt 之后执行,
+// 不满足时返回 FORBIDDEN 错误, 启用 audit 时鉴权结果以 module=audit 写入日志
+func NewPolicy(p PolicyParam) middleware.Middleware {
+	audit := log.NewHelper(log.With(p.Logger, "module", "audit"))
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			policy := p.Configuration.Policy.Get()
+			if !policy.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			claims, _ := contextutil.GetClaims(ctx)
+			reason := authorize(policy, tr.Operation(), claims, req)
+
+			if policy.Audit {
+				var userID, tenant string
+				if claims != nil {
+					userID, tenant = claims.UserID, claims.Tenant
+				}
+				audit.Infow(
+					"operation", tr.Operation(),
+					"user_id", userID,
+					"tenant", tenant,
+					"allowed", reason == "",
+					"reason", reason,
+				)
+			}
+
+			if reason != "" {
+				return nil, errorv1.ErrorForbidden("permission denied: %s", reason)
+			}
+
+			return handler(ctx, req)
+		}
+	}
+}
+
+// authorize 校验请求是否满足所有匹配的规则, 允许时返回空字符串, 否则返回拒绝的原因
+func authorize(policy *def.PolicyRules, operation string, claims *contextutil.Claims, req interface{}) string {
+	if claims == nil {
+		claims = &contextutil.Claims{}
+	}
+
+	matched := false
+	for _, rule := range policy.Rules {
+		if rule == nil || !matchOperation(rule.Operation, operation) {
+			continue
+		}
+		matched = true
+
+		if len(rule.Roles) != 0 && !slices.ContainsFunc(rule.Roles, func(role string) bool { return slices.Contains(claims.Roles, role) }) {
+			return fmt.Sprintf("one of roles %v is required", rule.Roles)
+		}
+
+		for _, permission := range rule.Permissions {
+			if !hasPermission(policy, claims, permission) {
+				return fmt.Sprintf("permission %s is required", permission)
+			}
+		}
+
+		for _, condition := range rule.Conditions {
+			if condition != nil && !matchCondition(condition, claims, req) {
+				return fmt.Sprintf("condition on field %s is not satisfied", condition.Field)
+			}
+		}
+	}
+
+	if !matched && policy.Default == def.PolicyDefaultDeny {
+		return "no policy rule matched"
+	}
+
+	return ""
+}
+
+// hasPermission 判断用户是否拥有权限, 权限来自令牌及用户角色
+func hasPermission(policy *def.PolicyRules, claims *contextutil.Claims, permission string) bool {
+	if slices.Contains(claims.Permissions, permission) {
+		return true
+	}
+
+	for _, role := range claims.Roles {
+		if slices.Contains(policy.RolePermissions[role], permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchCondition 判断请求字段是否满足条件, 字段不存在或请求不是 proto 消息时视为不满足
+func matchCondition(condition *def.PolicyCondition, claims *contextutil.Claims, req interface{}) bool {
+	value, ok := requestField(req, condition.Field)
+	if !ok {
+		return false
+	}
+
+	expand := func(expected string) string {
+		switch expected {
+		case "$user_id":
+			return claims.UserID
+		case "$tenant":
+			return claims.Tenant
+		default:
+			return expected
+		}
+	}
+
+	if condition.Equals != "" && value != expand(condition.Equals) {
+		return false
+	}
+
+	if len(condition.In) != 0 && !slices.ContainsFunc(condition.In, func(expected string) bool { return value == expand(expected) }) {
+		return false
+	}
+
+	return true
+}
+
+// requestField 使用 proto 字段名读取请求中的标量字段, 嵌套字段使用 . 分隔
+func requestField(req interface{}, path string) (string, bool) {
+	msg, ok := req.(proto.Message)
+	if !ok || path == "" {
+		return "", false
+	}
+
+	current := msg.ProtoReflect()
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := current.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil || field.IsList() || field.IsMap() {
+			return "", false
+		}
+
+		if i == len(names)-1 {
+			if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+				return "", false
+			}
+			return current.Get(field).String(), true
+		}
+
+		if field.Kind() != protoreflect.MessageKind || !current.Has(field) {
+			return "", false
+		}
+		current = current.Get(field).Message()
+	}
+
+	return "", false
+}
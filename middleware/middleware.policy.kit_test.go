@@ -0,0 +1,130 @@
+package middlewareutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	commondef "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	contextutil "github.com/eden-quan/go-biz-kit/context"
+)
+
+// go test -v -count=1 ./middleware -test.run=TestAuthorize
+func TestAuthorize(t *testing.T) {
+	policy := &def.Policy{}
+	require.NoError(t, policy.UnmarshalJSON([]byte(`
+enable: true
+default: deny
+role_permissions:
+  admin: [order.write]
+rules:
+  - operation: /api.order.v1.Order/*
+    roles: [admin, user]
+  - operation: /api.order.v1.Order/Update
+    permissions: [order.write]
+    conditions:
+      - field: grpc.name
+        equals: $tenant
+`)))
+	rules := policy.Get()
+	require.True(t, rules.GetEnable())
+
+	admin := &contextutil.Claims{Tenant: "t-1", Roles: []string{"admin"}}
+	user := &contextutil.Claims{Tenant: "t-1", Roles: []string{"user"}}
+	req := &def.Server{Grpc: &def.Registry{Name: "t-1"}}
+
+	require.Empty(t, authorize(rules, "/api.order.v1.Order/Get", user, req))
+	require.NotEmpty(t, authorize(rules, "/api.order.v1.Order/Get", nil, req))
+	require.NotEmpty(t, authorize(rules, "/api.user.v1.User/Get", admin, req))
+
+	require.Empty(t, authorize(rules, "/api.order.v1.Order/Update", admin, req))
+	require.NotEmpty(t, authorize(rules, "/api.order.v1.Order/Update", user, req))
+	require.NotEmpty(t, authorize(rules, "/api.order.v1.Order/Update", admin, &def.Server{Grpc: &def.Registry{Name: "t-2"}}))
+
+	// 解析失败时保留之前的策略
+	require.Error(t, policy.UnmarshalJSON([]byte("rules: [")))
+	require.Same(t, rules, policy.Get())
+}
+
+// newTransferRequest 创建 account 字段标记为 sensitive 的 proto 请求
+func newTransferRequest(t *testing.T, account string) proto.Message {
+	sensitive := &descriptorpb.FieldOptions{}
+	proto.SetExtension(sensitive, commondef.E_Sensitive, true)
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/bank.proto"),
+		Package: proto.String("api.bank.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("TransferRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("account"),
+				JsonName: proto.String("account"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Options:  sensitive,
+			}},
+		}},
+	}, nil)
+	require.NoError(t, err)
+
+	desc := file.Messages().ByName("TransferRequest")
+	msg := dynamicpb.NewMessage(desc)
+	msg.Set(desc.Fields().ByName("account"), protoreflect.ValueOfString(account))
+
+	return msg
+}
+
+// go test -v -count=1 ./middleware -test.run=TestPolicyOnSensitiveField
+func TestPolicyOnSensitiveField(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	configuration := &def.Configuration{}
+	configuration.Secret.TransferEncrypt = &commondef.Secret_TransferEncrypt{
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+	require.NoError(t, configuration.Policy.UnmarshalJSON([]byte(`
+enable: true
+rules:
+  - operation: /api.bank.v1.Bank/Transfer
+    conditions:
+      - field: account
+        equals: $user_id
+`)))
+
+	decrypt := NewDecrypt(DecryptParam{Configuration: configuration, Logger: log.DefaultLogger})
+	policy := NewPolicy(PolicyParam{Configuration: configuration, Logger: log.DefaultLogger})
+	handler := middleware.Chain(decrypt, policy)(okHandler)
+
+	transfer := func(account string) error {
+		cipher, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, []byte(account), nil)
+		require.NoError(t, err)
+
+		ctx, _ := newTestContext("/api.bank.v1.Bank/Transfer", "")
+		ctx = contextutil.WithClaims(ctx, &contextutil.Claims{UserID: "6222"})
+		_, err = handler(ctx, newTransferRequest(t, base64.StdEncoding.EncodeToString(cipher)))
+		return err
+	}
+
+	// 敏感字段在权限校验之前解密, 条件与明文比较
+	require.NoError(t, transfer("6222"))
+	require.Error(t, transfer("6333"))
+}
@@ -0,0 +1,208 @@
+package middlewareutil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	authpkg "github.com/eden-quan/go-kratos-pkg/auth"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"go.uber.org/fx"
+	"google.golang.org/grpc/peer"
+
+	kit "github.com/eden-quan/go-biz-kit"
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+const (
+	RateLimitBackendLocal = "local"
+	RateLimitBackendRedis = "redis"
+
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyToken  = "token"
+	RateLimitKeyHeader = "header:"
+
+	// RetryAfterKey 为限流后返回的重试等待时间 (秒), 同时设置在错误的 Metadata 及响应头中
+	RetryAfterKey = "Retry-After"
+)
+
+// rateLimiter 为限流的具体实现
+type rateLimiter interface {
+	// Allow 检查 key 是否允许通过, 不允许通过时返回需要等待的时间
+	Allow(ctx context.Context, key string, rule *def.RateLimitRule) (bool, time.Duration, error)
+}
+
+// RateLimitParam 为创建限流中间件所需的依赖, 未注入 Redis 时基于 Redis 的规则会退化为本地令牌桶
+type RateLimitParam struct {
+	fx.In
+
+	Configuration *def.Configuration
+	Local         *config.LocalConfigure
+	Logger        log.Logger
+	Redis         injection.Optional[kit.Redis] `optional:"true"`
+}
+
+// NewRateLimit 创建限流中间件, 限流规则通过配置中心 /middleware/ratelimit/config 配置, 并在每次请求时读取,
+// 请求被限制时返回 TOO_MANY_REQUESTS 错误，并通过 Retry-After 返回需要等待的秒数
+func NewRateLimit(p RateLimitParam) middleware.Middleware {
+	helper := log.NewHelper(log.With(p.Logger, "module", "ratelimit"))
+	local := newLocalLimiter()
+	fallback := sync.Once{}
+
+	var distributed rateLimiter
+	if rds, ok := p.Redis.Get(); ok && !kit.IsDisabled(rds) {
+		distributed = newRedisLimiter(rds.Get(), "ratelimit:"+p.Local.APP.Name+":")
+	}
+
+	limiterOf := func(rule *def.RateLimitRule) rateLimiter {
+		if rule.GetBackend() != RateLimitBackendRedis {
+			return local
+		}
+
+		if distributed == nil {
+			fallback.Do(func() {
+				helper.Warnw("msg", "redis is not available, fallback to local rate limiter", "operation", rule.GetOperation())
+			})
+			return local
+		}
+
+		return distributed
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			conf := &p.Configuration.RateLimit
+			if !conf.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			operation := tr.Operation()
+			for _, rule := range conf.GetRules() {
+				if rule.GetRate() <= 0 || !matchOperation(rule.GetOperation(), operation) {
+					continue
+				}
+
+				key := rule.GetOperation() + ":" + rule.GetKey() + ":" + rateLimitKey(ctx, tr, rule.GetKey(), conf.GetTrustedProxies())
+				allowed, wait, limitErr := limiterOf(rule).Allow(ctx, key, rule)
+				if limitErr != nil {
+					// 限流器异常时放行请求，避免限流组件影响业务的可用性
+					helper.Errorw("msg", "rate limiter failed", "operation", operation, "err", limitErr)
+					continue
+				}
+
+				if !allowed {
+					retryAfter := strconv.Itoa(int(math.Max(1, math.Ceil(wait.Seconds()))))
+					tr.ReplyHeader().Set(RetryAfterKey, retryAfter)
+
+					return nil, errorv1.ErrorTooManyRequests("too many requests, retry after %s seconds", retryAfter).
+						WithMetadata(map[string]string{RetryAfterKey: retryAfter})
+				}
+			}
+
+			return handler(ctx, req)
+		}
+	}
+}
+
+// matchOperation 检查 operation 是否匹配规则中的 pattern, pattern 为空或 * 时匹配所有接口, 以 * 结尾时按前缀匹配
+func matchOperation(pattern string, operation string) bool {
+	switch {
+	case pattern == "" || pattern == "*":
+		return true
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(operation, strings.TrimSuffix(pattern, "*"))
+	default:
+		return pattern == operation
+	}
+}
+
+// rateLimitKey 根据限流维度获取当前请求的限流键, 值为空的请求共用同一个限流桶, token 使用 SHA-256 摘要, 避免在 Redis 及内存中保存原始的 Token
+func rateLimitKey(ctx context.Context, tr transport.Transporter, key string, trustedProxies []string) string {
+	switch {
+	case key == RateLimitKeyIP:
+		return clientIP(ctx, tr, trustedProxies)
+	case key == RateLimitKeyToken:
+		token := tr.RequestHeader().Get(authpkg.AuthorizationKey)
+		if token == "" {
+			return ""
+		}
+		sum := sha256.Sum256([]byte(token))
+		return hex.EncodeToString(sum[:])
+	case strings.HasPrefix(key, RateLimitKeyHeader):
+		return tr.RequestHeader().Get(strings.TrimPrefix(key, RateLimitKeyHeader))
+	default:
+		return ""
+	}
+}
+
+// clientIP 获取客户端 IP, 默认使用连接的对端地址, 只有对端为受信任的代理时才使用代理设置的 X-Forwarded-For 及 X-Real-IP,
+// X-Forwarded-For 从右向左跳过受信任的代理, 返回第一个不受信任的地址, 避免客户端伪造请求头绕过限流
+func clientIP(ctx context.Context, tr transport.Transporter, trustedProxies []string) string {
+	addr := ""
+	if ht, ok := tr.(khttp.Transporter); ok {
+		addr = ht.Request().RemoteAddr
+	} else if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	if len(trustedProxies) == 0 || !trustedProxy(addr, trustedProxies) {
+		return addr
+	}
+
+	header := tr.RequestHeader()
+	if forwarded := header.Get("X-Forwarded-For"); forwarded != "" {
+		hops := strings.Split(forwarded, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop != "" && (i == 0 || !trustedProxy(hop, trustedProxies)) {
+				return hop
+			}
+		}
+	}
+	if realIP := strings.TrimSpace(header.Get("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+
+	return addr
+}
+
+// trustedProxy 判断 addr 是否属于 trustedProxies 中的 IP 或 CIDR
+func trustedProxy(addr string, trustedProxies []string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		if _, cidr, err := net.ParseCIDR(proxy); err == nil {
+			if cidr.Contains(ip) {
+				return true
+			}
+		} else if proxyIP := net.ParseIP(proxy); proxyIP != nil && proxyIP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,290 @@
+package middlewareutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kratoserrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	kit "github.com/eden-quan/go-biz-kit"
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+// testHeader 为测试使用的 transport.Header
+type testHeader nethttp.Header
+
+func (h testHeader) Get(key string) string        { return nethttp.Header(h).Get(key) }
+func (h testHeader) Set(key string, value string) { nethttp.Header(h).Set(key, value) }
+func (h testHeader) Add(key string, value string) { nethttp.Header(h).Add(key, value) }
+func (h testHeader) Values(key string) []string   { return nethttp.Header(h).Values(key) }
+func (h testHeader) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// testTransport 为测试使用的 GRPC 服务端 transport.Transporter
+type testTransport struct {
+	operation string
+	request   testHeader
+	reply     testHeader
+}
+
+func newTestContext(operation string, peerAddr string, headers ...string) (context.Context, *testTransport) {
+	tr := &testTransport{operation: operation, request: testHeader{}, reply: testHeader{}}
+	for i := 0; i+1 < len(headers); i += 2 {
+		tr.request.Set(headers[i], headers[i+1])
+	}
+
+	ctx := transport.NewServerContext(context.Background(), tr)
+	if peerAddr != "" {
+		addr, _ := net.ResolveTCPAddr("tcp", peerAddr)
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+
+	return ctx, tr
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return t.operation }
+func (t *testTransport) RequestHeader() transport.Header { return t.request }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.reply }
+
+// fakeRedis 通过 go-redis 的 Hook 在内存中实现中间件使用的命令, 不需要连接 Redis
+type fakeRedis struct {
+	lock   sync.Mutex
+	values map[string]string
+	zsets  map[string]map[string]int64 // 有序集合的成员及分数
+	err    error                       // 不为空时所有命令返回该错误, 用于模拟 Redis 异常
+}
+
+type fakeRedisClient struct {
+	db redis.UniversalClient
+}
+
+func (c fakeRedisClient) Get() redis.UniversalClient { return c.db }
+
+func newFakeRedis() (*fakeRedis, kit.Redis) {
+	f := &fakeRedis{values: map[string]string{}, zsets: map[string]map[string]int64{}}
+	db := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	db.AddHook(f)
+
+	return f, fakeRedisClient{db: db}
+}
+
+func (f *fakeRedis) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (f *fakeRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (f *fakeRedis) ProcessHook(redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		if f.err != nil {
+			cmd.SetErr(f.err)
+			return f.err
+		}
+
+		args := make([]string, len(cmd.Args()))
+		for i, arg := range cmd.Args() {
+			if data, ok := arg.([]byte); ok {
+				args[i] = string(data)
+			} else {
+				args[i] = fmt.Sprint(arg)
+			}
+		}
+
+		switch strings.ToLower(args[0]) {
+		case "get":
+			value, ok := f.values[args[1]]
+			if !ok {
+				cmd.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			cmd.(*redis.StringCmd).SetVal(value)
+		case "set":
+			_, exists := f.values[args[1]]
+			nx := false
+			for _, arg := range args[3:] {
+				nx = nx || strings.EqualFold(arg, "nx")
+			}
+			if !nx || !exists {
+				f.values[args[1]] = args[2]
+			}
+			switch c := cmd.(type) {
+			case *redis.BoolCmd:
+				c.SetVal(!nx || !exists)
+			case *redis.StatusCmd:
+				c.SetVal("OK")
+			}
+		case "evalsha":
+			keys, _ := strconv.Atoi(args[2])
+			cmd.(*redis.Cmd).SetVal(f.eval(args[1], args[3:3+keys], args[3+keys:]))
+		default:
+			err := errors.New("fake redis: unsupported command " + args[0])
+			cmd.SetErr(err)
+			return err
+		}
+
+		return nil
+	}
+}
+
+// eval 使用 Go 实现中间件中脚本的逻辑
+func (f *fakeRedis) eval(sha string, keys []string, argv []string) int64 {
+	switch sha {
+	case slidingWindowScript.Hash():
+		now, _ := strconv.ParseInt(argv[0], 10, 64)
+		window, _ := strconv.ParseInt(argv[1], 10, 64)
+		limit, _ := strconv.Atoi(argv[2])
+
+		members := make(map[string]int64)
+		oldest := now
+		for member, score := range f.zsets[keys[0]] {
+			if score > now-window {
+				members[member] = score
+				oldest = min(oldest, score)
+			}
+		}
+		f.zsets[keys[0]] = members
+
+		if len(members) < limit {
+			// 与 ZADD 一致, 成员已存在时只更新分数
+			members[argv[3]] = now
+			return 0
+		}
+		if wait := oldest + window - now; wait > 1 {
+			return wait
+		}
+		return 1
+	case releaseLockScript.Hash():
+		if f.values[keys[0]] == argv[0] {
+			delete(f.values, keys[0])
+			return 1
+		}
+		return 0
+	default:
+		panic("fake redis: unknown script " + sha)
+	}
+}
+
+func okHandler(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+// go test -v -count=1 ./middleware -test.run=TestRateLimit
+func TestRateLimit(t *testing.T) {
+	fake, rds := newFakeRedis()
+	configuration := &def.Configuration{}
+	local := &config.LocalConfigure{}
+	local.APP.Name = "order-service"
+
+	handler := NewRateLimit(RateLimitParam{
+		Configuration: configuration,
+		Local:         local,
+		Logger:        log.DefaultLogger,
+		Redis:         injection.Some(rds),
+	})(okHandler)
+
+	call := func(operation string, peerAddr string, headers ...string) (*testTransport, error) {
+		ctx, tr := newTestContext(operation, peerAddr, headers...)
+		_, err := handler(ctx, nil)
+		return tr, err
+	}
+
+	// 未启用时不限流
+	for i := 0; i < 5; i++ {
+		_, err := call("/api.Order/Create", "10.0.0.1:1000")
+		require.NoError(t, err)
+	}
+
+	// 本地令牌桶按 IP 限流, 超过 burst 后返回 Retry-After
+	configuration.RateLimit = def.RateLimit{
+		Enable: true,
+		Rules:  []*def.RateLimitRule{{Operation: "/api.Order/*", Key: RateLimitKeyIP, Rate: 1, Burst: 2}},
+	}
+	for i := 0; i < 2; i++ {
+		_, err := call("/api.Order/Create", "10.0.0.1:1000")
+		require.NoError(t, err)
+	}
+	tr, err := call("/api.Order/Create", "10.0.0.1:1000")
+	require.Equal(t, 429, int(kratoserrors.FromError(err).Code))
+	require.Equal(t, "1", tr.reply.Get(RetryAfterKey))
+	require.Equal(t, "1", kratoserrors.FromError(err).Metadata[RetryAfterKey])
+
+	// 不同的 IP 使用不同的令牌桶, 未匹配的接口不受限制
+	_, err = call("/api.Order/Create", "10.0.0.2:1000")
+	require.NoError(t, err)
+	_, err = call("/api.User/Get", "10.0.0.1:1000")
+	require.NoError(t, err)
+
+	// 客户端伪造的 X-Forwarded-For 不会改变限流桶
+	_, err = call("/api.Order/Create", "10.0.0.1:1000", "X-Forwarded-For", "1.2.3.4")
+	require.Error(t, err)
+
+	// 来自受信任代理的请求使用 X-Forwarded-For 中第一个不受信任的地址
+	configuration.RateLimit.TrustedProxies = []string{"10.0.0.0/24"}
+	_, err = call("/api.Order/Create", "10.0.0.1:1000", "X-Forwarded-For", "1.2.3.4, 10.0.0.9")
+	require.NoError(t, err)
+	ctx, tr := newTestContext("/api.Order/Create", "10.0.0.1:1000", "X-Forwarded-For", "1.2.3.4, 10.0.0.9")
+	require.Equal(t, "1.2.3.4", clientIP(ctx, tr, configuration.RateLimit.TrustedProxies))
+
+	// token 维度使用摘要作为限流键
+	ctx, tr = newTestContext("/api.Order/Create", "", "Authorization", "Bearer secret-token")
+	key := rateLimitKey(ctx, tr, RateLimitKeyToken, nil)
+	require.Len(t, key, 64)
+	require.NotContains(t, key, "secret-token")
+
+	// 修改配置后立即生效: Redis 滑动窗口, 窗口内允许 rate * window 个请求
+	configuration.RateLimit = def.RateLimit{
+		Enable: true,
+		Rules: []*def.RateLimitRule{{
+			Operation: "/api.Order/Create", Rate: 2, Backend: RateLimitBackendRedis, Window: durationpb.New(time.Second),
+		}},
+	}
+	for i := 0; i < 2; i++ {
+		_, err := call("/api.Order/Create", "10.0.0.3:1000")
+		require.NoError(t, err)
+	}
+	tr, err = call("/api.Order/Create", "10.0.0.4:1000")
+	require.Equal(t, 429, int(kratoserrors.FromError(err).Code))
+	require.Equal(t, "1", tr.reply.Get(RetryAfterKey))
+	require.Len(t, fake.zsets["ratelimit:order-service:/api.Order/Create::"], 2)
+
+	// 多个实例共享同一个窗口, 同一毫秒内的请求不会相互覆盖
+	rule := &def.RateLimitRule{Rate: 1000, Window: durationpb.New(time.Second)}
+	instances := []*redisLimiter{newRedisLimiter(rds.Get(), "ratelimit:shared:"), newRedisLimiter(rds.Get(), "ratelimit:shared:")}
+	for i := 0; i < 100; i++ {
+		for _, limiter := range instances {
+			allowed, _, err := limiter.Allow(context.Background(), "order", rule)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		}
+	}
+	require.Len(t, fake.zsets["ratelimit:shared:order"], 200)
+
+	// Redis 异常时放行
+	fake.err = errors.New("connection refused")
+	_, err = call("/api.Order/Create", "10.0.0.3:1000")
+	require.NoError(t, err)
+}
@@ -0,0 +1,131 @@
+package middlewareutil
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+const (
+	// 本地令牌桶的清理间隔 (次数) 及空闲时间
+	localLimiterPruneEvery = 1024
+	localLimiterIdle       = time.Minute * 10
+)
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// localLimiter 为基于令牌桶的本地限流器, 每个限流键使用独立的令牌桶, 长时间未使用的令牌桶会被清理
+type localLimiter struct {
+	lock    sync.Mutex
+	buckets map[string]*tokenBucket
+	checks  int
+}
+
+func newLocalLimiter() *localLimiter {
+	return &localLimiter{
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (l *localLimiter) Allow(_ context.Context, key string, rule *def.RateLimitRule) (bool, time.Duration, error) {
+	rate := rule.GetRate()
+	burst := float64(rule.GetBurst())
+	if burst <= 0 {
+		burst = math.Ceil(rate)
+	}
+
+	now := time.Now()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+
+	// 规则修改后令牌数不会超过新的容量
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens -= 1
+		return true, 0, nil
+	}
+
+	return false, time.Duration((1 - b.tokens) / rate * float64(time.Second)), nil
+}
+
+func (l *localLimiter) prune(now time.Time) {
+	l.checks += 1
+	if l.checks%localLimiterPruneEvery != 0 {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > localLimiterIdle {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// slidingWindowScript 使用有序集合记录窗口内的请求, 允许通过时返回 0, 否则返回需要等待的毫秒数
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, 0, now - window)
+if redis.call('ZCARD', key) < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+	return 0
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+return math.max(1, tonumber(oldest[2]) + window - now)
+`)
+
+// redisLimiter 为基于 Redis 滑动窗口的分布式限流器, 所有实例共享同一个窗口
+type redisLimiter struct {
+	db     redis.UniversalClient
+	prefix string
+	id     string // 实例的随机标识, 避免不同实例在同一毫秒写入相同的成员而相互覆盖
+	seq    atomic.Int64
+}
+
+func newRedisLimiter(db redis.UniversalClient, prefix string) *redisLimiter {
+	return &redisLimiter{db: db, prefix: prefix, id: newLockToken()}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, rule *def.RateLimitRule) (bool, time.Duration, error) {
+	window := time.Second
+	if rule.GetWindow() != nil && rule.GetWindow().AsDuration() > 0 {
+		window = rule.GetWindow().AsDuration()
+	}
+
+	limit := int64(math.Max(1, math.Floor(rule.GetRate()*window.Seconds())))
+	now := time.Now().UnixMilli()
+	member := strconv.FormatInt(now, 10) + "-" + l.id + "-" + strconv.FormatInt(l.seq.Add(1), 10)
+
+	wait, err := slidingWindowScript.Run(ctx, l.db, []string{l.prefix + key}, now, window.Milliseconds(), limit, member).Int64()
+	if err != nil {
+		return false, 0, err
+	}
+
+	return wait == 0, time.Duration(wait) * time.Millisecond, nil
+}
@@ -0,0 +1,464 @@
+package middlewareutil
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+const (
+	// SensitiveTag 非 proto 请求中标记敏感字段的结构体标签, 支持 string、*string 及 []string 字段, 如 Password string `sensitive:"true"`
+	SensitiveTag = "sensitive"
+	// MaskedValue 敏感字段在日志中记录的值
+	MaskedValue = "******"
+)
+
+// 消息类型是否包含敏感字段, key 为 protoreflect.FullName 或 reflect.Type
+var sensitiveTypes sync.Map
+
+// DecryptParam 为创建敏感字段解密中间件所需的依赖
+type DecryptParam struct {
+	fx.In
+
+	Configuration *def.Configuration
+	Logger        log.Logger
+}
+
+// NewDecrypt 创建敏感字段解密中间件, 使用 /basic/secret 中 transfer_encrypt 的私钥解密请求中的敏感字段,
+// proto 消息通过字段选项 (kit.default.configv1.sensitive) 标记, 其他结构体通过 `sensitive:"true"` 标签标记,
+// 字段值为调用方使用公钥加密 (RSA-OAEP SHA256) 后的 base64 编码, 解密失败时返回 INVALID_PARAMETER 错误,
+// 解密结果写入请求的副本并只传递给后续的处理, 外层的中间件 (如 recovery 记录的请求) 仍然只能看到密文
+func NewDecrypt(p DecryptParam) middleware.Middleware {
+	helper := log.NewHelper(log.With(p.Logger, "module", "sensitive"))
+	keys := &transferKeyCache{}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			if !hasSensitive(req) {
+				return handler(ctx, req)
+			}
+
+			key, keyErr := keys.get(p.Configuration.Secret.GetTransferEncrypt().GetPrivateKey())
+			if keyErr != nil {
+				helper.Errorw("msg", "load transfer private key failed", "err", keyErr)
+				return nil, errorv1.ErrorFatal("transfer private key is not available")
+			}
+
+			decrypt := func(value string) (string, error) {
+				return decryptField(key, value)
+			}
+
+			decrypted, err := copySensitive(req, decrypt)
+			if err != nil {
+				return nil, errorv1.ErrorInvalidParameter("decrypt sensitive field failed: %s", err)
+			}
+
+			return handler(ctx, decrypted)
+		}
+	}
+}
+
+// MaskLog 将脱敏后的请求交给日志中间件 logger 记录, 后续的中间件及业务处理仍然使用原始的请求
+func MaskLog(logger middleware.Middleware) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			if !hasSensitive(req) {
+				return logger(handler)(ctx, req)
+			}
+
+			next := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				return handler(ctx, req)
+			}
+
+			return logger(next)(ctx, Mask(req))
+		}
+	}
+}
+
+// Mask 返回敏感字段替换为 MaskedValue 的请求副本, 请求中没有敏感字段时返回原始请求
+func Mask(req interface{}) interface{} {
+	if !hasSensitive(req) {
+		return req
+	}
+
+	mask := func(value string) (string, error) {
+		if value == "" {
+			return value, nil
+		}
+		return MaskedValue, nil
+	}
+
+	masked, _ := copySensitive(req, mask)
+	return masked
+}
+
+// copySensitive 返回使用 fn 替换敏感字段后的请求副本, 原始请求不会被修改
+func copySensitive(req interface{}, fn func(string) (string, error)) (interface{}, error) {
+	if msg, ok := req.(proto.Message); ok {
+		cloned := proto.Clone(msg)
+		return cloned, walkSensitive(cloned, fn, false)
+	}
+
+	rv := reflect.ValueOf(req)
+	cloned := reflect.New(rv.Elem().Type())
+	cloned.Elem().Set(rv.Elem())
+
+	return cloned.Interface(), walkSensitive(cloned.Interface(), fn, true)
+}
+
+// decryptField 解密 base64 编码的 RSA-OAEP 密文, 空值不做处理
+func decryptField(key *rsa.PrivateKey, value string) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+
+	cipher, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, cipher, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// walkSensitive 使用 fn 替换请求中所有敏感字段的值, copyNested 为 true 时在修改非 proto 请求的嵌套指针前先复制, 避免修改原始请求
+func walkSensitive(req interface{}, fn func(string) (string, error), copyNested bool) error {
+	if msg, ok := req.(proto.Message); ok {
+		return walkMessage(msg.ProtoReflect(), fn)
+	}
+
+	rv := reflect.ValueOf(req)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	return walkStruct(rv.Elem(), fn, copyNested)
+}
+
+func walkMessage(msg protoreflect.Message, fn func(string) (string, error)) error {
+	// Range 期间修改消息的行为未定义, 因此先收集字段
+	fields := make([]protoreflect.FieldDescriptor, 0)
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		value := msg.Get(fd)
+
+		switch {
+		case fd.Kind() == protoreflect.StringKind && isSensitiveField(fd) && fd.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				s, err := fn(list.Get(i).String())
+				if err != nil {
+					return err
+				}
+				list.Set(i, protoreflect.ValueOfString(s))
+			}
+		case fd.Kind() == protoreflect.StringKind && isSensitiveField(fd) && !fd.IsMap():
+			s, err := fn(value.String())
+			if err != nil {
+				return err
+			}
+			msg.Set(fd, protoreflect.ValueOfString(s))
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			var err error
+			value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				err = walkMessage(v.Message(), fn)
+				return err == nil
+			})
+			if err != nil {
+				return err
+			}
+		case fd.Message() != nil && fd.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				if err := walkMessage(list.Get(i).Message(), fn); err != nil {
+					return err
+				}
+			}
+		case fd.Message() != nil:
+			if err := walkMessage(value.Message(), fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkStruct 处理标记为敏感的 string、*string 及 []string 字段, 并递归处理嵌套的结构体、结构体指针及其切片,
+// 支持的字段类型需要与 structHasSensitive 保持一致, 否则会出现检测到敏感字段却未处理的情况
+func walkStruct(rv reflect.Value, fn func(string) (string, error), copyNested bool) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rv.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		if rt.Field(i).Tag.Get(SensitiveTag) == "true" {
+			if err := walkSensitiveValue(field, fn, copyNested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !typeHasSensitive(field.Type(), map[reflect.Type]bool{}) {
+			continue
+		}
+
+		if err := walkNested(field, fn, copyNested); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkSensitiveValue 替换标记为敏感的字段的值, copyNested 为 true 时先复制指针及切片
+func walkSensitiveValue(field reflect.Value, fn func(string) (string, error), copyNested bool) error {
+	switch {
+	case field.Kind() == reflect.String:
+		s, err := fn(field.String())
+		if err != nil {
+			return err
+		}
+		field.SetString(s)
+	case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.String:
+		if field.IsNil() {
+			return nil
+		}
+		s, err := fn(field.Elem().String())
+		if err != nil {
+			return err
+		}
+		if copyNested {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field.Elem().SetString(s)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		if copyNested {
+			copySlice(field)
+		}
+		for j := 0; j < field.Len(); j++ {
+			s, err := fn(field.Index(j).String())
+			if err != nil {
+				return err
+			}
+			field.Index(j).SetString(s)
+		}
+	}
+
+	return nil
+}
+
+// walkNested 递归处理包含敏感字段的结构体、结构体指针及其切片, copyNested 为 true 时先复制指针及切片
+func walkNested(field reflect.Value, fn func(string) (string, error), copyNested bool) error {
+	switch field.Kind() {
+	case reflect.Struct:
+		return walkStruct(field, fn, copyNested)
+	case reflect.Pointer:
+		if field.IsNil() || field.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		if copyNested {
+			copied := reflect.New(field.Elem().Type())
+			copied.Elem().Set(field.Elem())
+			field.Set(copied)
+		}
+		return walkStruct(field.Elem(), fn, copyNested)
+	case reflect.Slice:
+		if copyNested {
+			copySlice(field)
+		}
+		for j := 0; j < field.Len(); j++ {
+			if err := walkNested(field.Index(j), fn, copyNested); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func copySlice(field reflect.Value) {
+	if field.IsNil() {
+		return
+	}
+
+	copied := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+	reflect.Copy(copied, field)
+	field.Set(copied)
+}
+
+// hasSensitive 判断请求的类型是否包含敏感字段, 结果按类型缓存
+func hasSensitive(req interface{}) bool {
+	if msg, ok := req.(proto.Message); ok {
+		desc := msg.ProtoReflect().Descriptor()
+		if cached, ok := sensitiveTypes.Load(desc.FullName()); ok {
+			return cached.(bool)
+		}
+
+		result := messageHasSensitive(desc, map[protoreflect.FullName]bool{})
+		sensitiveTypes.Store(desc.FullName(), result)
+		return result
+	}
+
+	rt := reflect.TypeOf(req)
+	if rt == nil || rt.Kind() != reflect.Pointer || rt.Elem().Kind() != reflect.Struct || reflect.ValueOf(req).IsNil() {
+		return false
+	}
+
+	if cached, ok := sensitiveTypes.Load(rt); ok {
+		return cached.(bool)
+	}
+
+	result := structHasSensitive(rt.Elem(), map[reflect.Type]bool{})
+	sensitiveTypes.Store(rt, result)
+	return result
+}
+
+func messageHasSensitive(desc protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) bool {
+	if visited[desc.FullName()] {
+		return false
+	}
+	visited[desc.FullName()] = true
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+
+		if fd.Kind() == protoreflect.StringKind && isSensitiveField(fd) {
+			return true
+		}
+		if fd.Message() != nil && messageHasSensitive(fd.Message(), visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func structHasSensitive(rt reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[rt] {
+		return false
+	}
+	visited[rt] = true
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Tag.Get(SensitiveTag) == "true" && isSensitiveKind(field.Type) {
+			return true
+		}
+		if typeHasSensitive(field.Type, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSensitiveKind 判断标记为敏感的字段是否为 walkSensitiveValue 支持的 string、*string 或 []string
+func isSensitiveKind(ft reflect.Type) bool {
+	if ft.Kind() == reflect.Pointer || ft.Kind() == reflect.Slice {
+		ft = ft.Elem()
+	}
+
+	return ft.Kind() == reflect.String
+}
+
+// typeHasSensitive 判断结构体、结构体指针及其切片中是否包含敏感字段
+func typeHasSensitive(ft reflect.Type, visited map[reflect.Type]bool) bool {
+	if ft.Kind() == reflect.Slice {
+		ft = ft.Elem()
+	}
+	if ft.Kind() == reflect.Pointer {
+		ft = ft.Elem()
+	}
+
+	return ft.Kind() == reflect.Struct && structHasSensitive(ft, visited)
+}
+
+func isSensitiveField(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return false
+	}
+
+	sensitive, _ := proto.GetExtension(opts, errorv1.E_Sensitive).(bool)
+	return sensitive
+}
+
+// transferKeyCache 缓存解析后的私钥, 私钥变化时重新解析
+type transferKeyCache struct {
+	lock sync.Mutex
+	pem  string
+	key  *rsa.PrivateKey
+}
+
+func (c *transferKeyCache) get(content string) (*rsa.PrivateKey, error) {
+	if content == "" {
+		return nil, errors.New("transfer private key is not configured")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if content == c.pem {
+		return c.key, nil
+	}
+
+	block, _ := pem.Decode([]byte(content))
+	if block == nil {
+		return nil, errors.New("transfer private key is not a valid PEM")
+	}
+
+	var key *rsa.PrivateKey
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("transfer private key must be a RSA key")
+		}
+		key = rsaKey
+	} else if rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		key = rsaKey
+	} else {
+		return nil, errors.New("transfer private key must be a PKCS8 or PKCS1 RSA key")
+	}
+
+	c.pem, c.key = content, key
+	return key, nil
+}
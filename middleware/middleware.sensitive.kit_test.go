@@ -0,0 +1,132 @@
+package middlewareutil
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/stretchr/testify/require"
+
+	commondef "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+type loginProfile struct {
+	IDCard string `sensitive:"true"`
+}
+
+type loginRequest struct {
+	Name     string
+	Password string `sensitive:"true"`
+	Profile  *loginProfile
+}
+
+type resetRequest struct {
+	Code      *string  `sensitive:"true"`
+	Answers   []string `sensitive:"true"`
+	Profiles  []*loginProfile
+	Contacts  []loginProfile
+	Reference *string
+}
+
+// go test -v -count=1 ./middleware -test.run=TestSensitive
+func TestSensitive(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	encrypt := func(value string) string {
+		cipher, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, []byte(value), nil)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(cipher)
+	}
+
+	req := &loginRequest{Name: "eden", Password: encrypt("123456"), Profile: &loginProfile{IDCard: encrypt("110")}}
+	require.True(t, hasSensitive(req))
+	require.True(t, hasSensitive(&loginProfile{}))
+	require.False(t, hasSensitive(&struct{ Name string }{}))
+
+	require.NoError(t, walkSensitive(req, func(value string) (string, error) { return decryptField(key, value) }, false))
+	require.Equal(t, "123456", req.Password)
+	require.Equal(t, "110", req.Profile.IDCard)
+
+	var logged, handled interface{}
+	logger := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			logged = req
+			return handler(ctx, req)
+		}
+	}
+	handler := func(_ context.Context, req interface{}) (interface{}, error) {
+		handled = req
+		return nil, nil
+	}
+
+	_, err = MaskLog(logger)(handler)(context.Background(), req)
+	require.NoError(t, err)
+	require.Same(t, req, handled)
+	require.Equal(t, MaskedValue, logged.(*loginRequest).Password)
+	require.Equal(t, MaskedValue, logged.(*loginRequest).Profile.IDCard)
+	require.Equal(t, "eden", logged.(*loginRequest).Name)
+	require.Equal(t, "123456", req.Password)
+	require.Equal(t, "110", req.Profile.IDCard)
+
+	// 指针、切片及结构体切片中的敏感字段同样会被解密及脱敏
+	code := encrypt("8888")
+	reset := &resetRequest{
+		Code:     &code,
+		Answers:  []string{encrypt("blue")},
+		Profiles: []*loginProfile{{IDCard: encrypt("120")}},
+		Contacts: []loginProfile{{IDCard: encrypt("130")}},
+	}
+	require.True(t, hasSensitive(reset))
+	require.False(t, hasSensitive(&struct {
+		Reference *string
+		Tags      []int `sensitive:"true"`
+	}{}))
+
+	require.NoError(t, walkSensitive(reset, func(value string) (string, error) { return decryptField(key, value) }, false))
+	require.Equal(t, "8888", *reset.Code)
+	require.Equal(t, []string{"blue"}, reset.Answers)
+	require.Equal(t, "120", reset.Profiles[0].IDCard)
+	require.Equal(t, "130", reset.Contacts[0].IDCard)
+
+	masked := Mask(reset).(*resetRequest)
+	require.Equal(t, MaskedValue, *masked.Code)
+	require.Equal(t, []string{MaskedValue}, masked.Answers)
+	require.Equal(t, MaskedValue, masked.Profiles[0].IDCard)
+	require.Equal(t, MaskedValue, masked.Contacts[0].IDCard)
+	require.Equal(t, "8888", *reset.Code)
+	require.Equal(t, []string{"blue"}, reset.Answers)
+	require.Equal(t, "120", reset.Profiles[0].IDCard)
+	require.Equal(t, "130", reset.Contacts[0].IDCard)
+
+	require.Error(t, walkSensitive(&loginRequest{Password: "plain"}, func(value string) (string, error) { return decryptField(key, value) }, false))
+
+	// 中间件只将解密后的副本交给后续的处理, 外层 (如 recovery) 持有的请求仍然是密文
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	configuration := &def.Configuration{}
+	configuration.Secret.TransferEncrypt = &commondef.Secret_TransferEncrypt{
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+	decrypt := NewDecrypt(DecryptParam{Configuration: configuration, Logger: log.DefaultLogger})
+
+	cipher := encrypt("654321")
+	outer := &loginRequest{Name: "eden", Password: cipher, Profile: &loginProfile{IDCard: encrypt("140")}}
+	_, err = decrypt(handler)(context.Background(), outer)
+	require.NoError(t, err)
+	require.Equal(t, "654321", handled.(*loginRequest).Password)
+	require.Equal(t, "140", handled.(*loginRequest).Profile.IDCard)
+	require.Equal(t, cipher, outer.Password)
+	require.NotEqual(t, "140", outer.Profile.IDCard)
+
+	_, err = decrypt(handler)(context.Background(), &loginRequest{Password: "plain"})
+	require.Error(t, err)
+}
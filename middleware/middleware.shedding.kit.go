@@ -0,0 +1,119 @@
+package middlewareutil
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/aegis/ratelimit"
+	"github.com/go-kratos/aegis/ratelimit/bbr"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// shedCounter 记录因过载保护被拒绝的请求数
+var shedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "biz_kit",
+	Subsystem: "server",
+	Name:      "shed_requests_total",
+	Help:      "The total number of requests rejected by adaptive load shedding.",
+}, []string{"kind", "operation"})
+
+// sheddingKey 为创建限流器使用的参数, 参数变化时重新创建限流器, 使用可比较的结构体以避免每次请求都进行反射比较
+type sheddingKey struct {
+	window    time.Duration
+	bucket    int32
+	threshold int64
+	quota     float64
+}
+
+func newSheddingKey(conf *def.LoadShedding) sheddingKey {
+	return sheddingKey{
+		window:    conf.GetWindow().AsDuration(),
+		bucket:    conf.GetBucket(),
+		threshold: conf.GetCpuThreshold(),
+		quota:     conf.GetCpuQuota(),
+	}
+}
+
+// sheddingState 为当前使用的限流器及其参数
+type sheddingState struct {
+	key     sheddingKey
+	limiter ratelimit.Limiter
+}
+
+// sheddingLimiter 为根据配置创建的 BBR 限流器, 配置变更时会重新创建, 请求路径上只进行原子读取及参数比较
+type sheddingLimiter struct {
+	state atomic.Pointer[sheddingState]
+}
+
+// newSheddingLimiter 根据配置创建限流器
+var newSheddingLimiter = func(key sheddingKey) ratelimit.Limiter {
+	opts := make([]bbr.Option, 0)
+	if key.window > 0 {
+		opts = append(opts, bbr.WithWindow(key.window))
+	}
+	if key.bucket > 0 {
+		opts = append(opts, bbr.WithBucket(int(key.bucket)))
+	}
+	if key.threshold > 0 {
+		opts = append(opts, bbr.WithCPUThreshold(key.threshold))
+	}
+	if key.quota > 0 {
+		opts = append(opts, bbr.WithCPUQuota(key.quota))
+	}
+
+	return bbr.NewLimiter(opts...)
+}
+
+func (s *sheddingLimiter) get(conf *def.LoadShedding) ratelimit.Limiter {
+	key := newSheddingKey(conf)
+	if state := s.state.Load(); state != nil && state.key == key {
+		return state.limiter
+	}
+
+	// 配置变更时可能有多个请求同时创建, 以最后写入的为准
+	state := &sheddingState{key: key, limiter: newSheddingLimiter(key)}
+	s.state.Store(state)
+	return state.limiter
+}
+
+// LoadShedding 是基于 BBR 的自适应过载保护中间件, 在 CPU 使用率超过阈值且并发数超过系统的处理能力时拒绝请求,
+// conf 在每次请求时调用, 因此可以通过配置中心动态开关及调整参数, 被拒绝的请求返回 SERVICE_UNAVAILABLE 错误,
+// 并记录在 biz_kit_server_shed_requests_total 指标中
+func LoadShedding(kind string, conf func() *def.LoadShedding, logger log.Logger) middleware.Middleware {
+	helper := log.NewHelper(log.With(logger, "module", "shedding"))
+	limiter := &sheddingLimiter{}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			c := conf()
+			if !c.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			done, limitErr := limiter.get(c).Allow()
+			if limitErr != nil {
+				operation := ""
+				if tr, ok := transport.FromServerContext(ctx); ok {
+					operation = tr.Operation()
+				}
+
+				shedCounter.WithLabelValues(kind, operation).Inc()
+				helper.Warnw("msg", "request rejected by load shedding", "operation", operation)
+				return nil, errorv1.ErrorServiceUnavailable("service overloaded, please retry later")
+			}
+
+			reply, err = handler(ctx, req)
+			done(ratelimit.DoneInfo{Err: err})
+
+			return reply, err
+		}
+	}
+}
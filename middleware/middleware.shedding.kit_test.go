@@ -0,0 +1,68 @@
+package middlewareutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kratos/aegis/ratelimit"
+	kratoserrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// overloadLimiter 模拟过载时拒绝所有请求的限流器
+type overloadLimiter struct {
+	overload bool
+}
+
+func (l *overloadLimiter) Allow() (ratelimit.DoneFunc, error) {
+	if l.overload {
+		return nil, ratelimit.ErrLimitExceed
+	}
+	return func(ratelimit.DoneInfo) {}, nil
+}
+
+// go test -v -count=1 ./middleware -test.run=TestLoadShedding
+func TestLoadShedding(t *testing.T) {
+	created := make([]sheddingKey, 0)
+	limiter := &overloadLimiter{}
+	defer func(origin func(sheddingKey) ratelimit.Limiter) { newSheddingLimiter = origin }(newSheddingLimiter)
+	newSheddingLimiter = func(key sheddingKey) ratelimit.Limiter {
+		created = append(created, key)
+		return limiter
+	}
+
+	conf := &def.LoadShedding{}
+	handler := LoadShedding("grpc", func() *def.LoadShedding { return conf }, log.DefaultLogger)(okHandler)
+	ctx, _ := newTestContext("/api.Order/Create", "")
+	counter := shedCounter.WithLabelValues("grpc", "/api.Order/Create")
+	before := testutil.ToFloat64(counter)
+
+	// 未启用时不创建限流器
+	limiter.overload = true
+	_, err := handler(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, created)
+
+	// 过载时拒绝请求并记录指标
+	conf.Enable = true
+	_, err = handler(ctx, nil)
+	require.Equal(t, 503, int(kratoserrors.FromError(err).Code))
+	require.Equal(t, before+1, testutil.ToFloat64(counter))
+
+	limiter.overload = false
+	_, err = handler(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+
+	// 参数变化后重新创建限流器
+	conf.Window = durationpb.New(time.Second * 5)
+	_, err = handler(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	require.Equal(t, time.Second*5, created[1].window)
+}
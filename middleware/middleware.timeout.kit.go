@@ -0,0 +1,36 @@
+package middlewareutil
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// Timeout 是接口超时中间件, 根据 registry 返回的配置为每个接口设置超时时间, 具体见 def.Registry.OperationTimeout,
+// registry 在每次请求时调用，因此通过配置中心修改 operation_timeouts 后无需重启即可生效, 超时后返回 GATEWAY_TIMEOUT 错误
+func Timeout(registry func() *def.Registry) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			timeout := registry().OperationTimeout(tr.Operation())
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			reply, err = handler(ctx, req)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, errorv1.ErrorGatewayTimeout("operation %s timeout after %s", tr.Operation(), timeout)
+			}
+
+			return reply, err
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package payload
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// DefaultMaxSize 单个内容记录的默认最大字节数
+const DefaultMaxSize = 4096
+
+// config 返回当前的内容日志配置, 由 Setup 设置
+var config atomic.Pointer[func() *def.Log_Payload]
+
+// Setup 设置内容日志的配置, conf 返回 /middleware/log/config 中当前的 payload 配置, 未调用时不记录内容
+func Setup(conf func() *def.Log_Payload) {
+	config.Store(&conf)
+}
+
+// current 返回当前的配置, 未启用或未命中采样时返回 nil
+func current() *def.Log_Payload {
+	conf := config.Load()
+	if conf == nil {
+		return nil
+	}
+
+	payload := (*conf)()
+	if !payload.GetEnable() {
+		return nil
+	}
+
+	if rate := payload.GetSampleRate(); rate > 0 && rate < 1 && rand.Float64() >= rate {
+		return nil
+	}
+
+	return payload
+}
+
+// Server 记录服务端的请求及响应内容, 内容按照配置脱敏及截断
+func Server(logger log.Logger) middleware.Middleware {
+	helper := log.NewHelper(log.With(logger, "module", "payload"))
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			conf := current()
+			tr, ok := transport.FromServerContext(ctx)
+			if conf == nil || !ok {
+				return handler(ctx, req)
+			}
+
+			return observe(ctx, req, handler, conf, helper, "server", tr)
+		}
+	}
+}
+
+// Client 记录客户端的请求及响应内容, 内容按照配置脱敏及截断
+func Client(logger log.Logger) middleware.Middleware {
+	helper := log.NewHelper(log.With(logger, "module", "payload"))
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			conf := current()
+			tr, ok := transport.FromClientContext(ctx)
+			if conf == nil || !ok {
+				return handler(ctx, req)
+			}
+
+			return observe(ctx, req, handler, conf, helper, "client", tr)
+		}
+	}
+}
+
+func observe(
+	ctx context.Context,
+	req interface{},
+	handler middleware.Handler,
+	conf *def.Log_Payload,
+	helper *log.Helper,
+	side string,
+	tr transport.Transporter,
+) (interface{}, error) {
+	start := time.Now()
+	reply, err := handler(ctx, req)
+
+	keyvals := []interface{}{
+		"side", side,
+		"kind", tr.Kind().String(),
+		"operation", tr.Operation(),
+		"latency", time.Since(start).Seconds(),
+		"request", format(req, conf),
+	}
+	if err != nil {
+		keyvals = append(keyvals, "error", err.Error())
+	} else {
+		keyvals = append(keyvals, "reply", format(reply, conf))
+	}
+
+	helper.WithContext(ctx).Infow(keyvals...)
+
+	return reply, err
+}
+
+// Message 记录消息队列的消息内容, action 为 publish 或 consume, 内容按照配置脱敏及截断
+func Message(logger log.Logger, action string, exchange string, routingKey string, body []byte) {
+	conf := current()
+	if conf == nil {
+		return
+	}
+
+	log.NewHelper(log.With(logger, "module", "payload")).Infow(
+		"side", "mq",
+		"action", action,
+		"exchange", exchange,
+		"routing_key", routingKey,
+		"payload", format(body, conf),
+	)
+}
+
+// format 返回脱敏并截断后的内容
+func format(v interface{}, conf *def.Log_Payload) string {
+	data, err := Redact(v, conf.GetRedactFields())
+	if err != nil {
+		return "<marshal payload failed: " + err.Error() + ">"
+	}
+
+	limit := int(conf.GetMaxSize())
+	if limit <= 0 {
+		limit = DefaultMaxSize
+	}
+
+	if len(data) > limit {
+		return string(data[:limit]) + "...(truncated " + strconv.Itoa(len(data)-limit) + " bytes)"
+	}
+
+	return string(data)
+}
@@ -0,0 +1,129 @@
+package payload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// recordLogger 记录每条日志的字段
+type recordLogger struct {
+	lock    sync.Mutex
+	records []map[string]interface{}
+}
+
+func (l *recordLogger) Log(_ log.Level, keyvals ...interface{}) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	record := make(map[string]interface{})
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		record[fmt.Sprint(keyvals[i])] = keyvals[i+1]
+	}
+	l.records = append(l.records, record)
+
+	return nil
+}
+
+type testHeader map[string]string
+
+func (h testHeader) Get(key string) string      { return h[key] }
+func (h testHeader) Set(key, value string)      { h[key] = value }
+func (h testHeader) Add(key, value string)      { h[key] = value }
+func (h testHeader) Keys() []string             { return nil }
+func (h testHeader) Values(key string) []string { return []string{h[key]} }
+
+type testTransport struct{}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "/api.user.v1.User/Login" }
+func (t *testTransport) RequestHeader() transport.Header { return testHeader{} }
+func (t *testTransport) ReplyHeader() transport.Header   { return testHeader{} }
+
+// go test -v -count=1 ./payload -test.run=TestMiddleware
+func TestMiddleware(t *testing.T) {
+	conf := &def.Log_Payload{}
+	Setup(func() *def.Log_Payload { return conf })
+	defer config.Store(nil)
+
+	logger := &recordLogger{}
+	login := func(context.Context, interface{}) (interface{}, error) {
+		return map[string]string{"access_token": "t-123", "name": "eden"}, nil
+	}
+	serverCtx := transport.NewServerContext(context.Background(), &testTransport{})
+	req := &def.Redis{Username: "eden", Password: "123456"}
+
+	// 未启用时不记录
+	_, err := Server(logger)(login)(serverCtx, req)
+	require.NoError(t, err)
+	require.Empty(t, logger.records)
+
+	// 服务端记录脱敏后的请求及响应
+	conf.Enable = true
+	_, err = Server(logger)(login)(serverCtx, req)
+	require.NoError(t, err)
+	require.Len(t, logger.records, 1)
+	record := logger.records[0]
+	require.Equal(t, "server", record["side"])
+	require.Equal(t, "/api.user.v1.User/Login", record["operation"])
+	require.Contains(t, record["request"], `"username":"eden"`)
+	require.NotContains(t, record["request"], "123456")
+	require.NotContains(t, record["reply"], "t-123")
+
+	// 客户端只处理客户端的请求, 失败时记录错误而不是响应
+	clientCtx := transport.NewClientContext(context.Background(), &testTransport{})
+	_, err = Client(logger)(func(context.Context, interface{}) (interface{}, error) {
+		return nil, errors.New("connection refused")
+	})(clientCtx, []byte("raw body"))
+	require.Error(t, err)
+	require.Len(t, logger.records, 2)
+	record = logger.records[1]
+	require.Equal(t, "client", record["side"])
+	require.Equal(t, "<8 bytes>", record["request"])
+	require.Equal(t, "connection refused", record["error"])
+	require.NotContains(t, record, "reply")
+
+	_, err = Client(logger)(login)(serverCtx, req)
+	require.NoError(t, err)
+	require.Len(t, logger.records, 2)
+}
+
+// go test -v -count=1 ./payload -test.run=TestSampling
+func TestSampling(t *testing.T) {
+	conf := &def.Log_Payload{Enable: true}
+	Setup(func() *def.Log_Payload { return conf })
+	defer config.Store(nil)
+
+	sampled := func() int {
+		count := 0
+		for i := 0; i < 1000; i++ {
+			if current() != nil {
+				count++
+			}
+		}
+		return count
+	}
+
+	// 未配置或配置不在 (0, 1) 内时记录所有请求
+	require.Equal(t, 1000, sampled())
+	conf.SampleRate = 1
+	require.Equal(t, 1000, sampled())
+
+	conf.SampleRate = 0.2
+	count := sampled()
+	require.Greater(t, count, 100)
+	require.Less(t, count, 300)
+
+	// 配置修改后立即生效
+	conf.Enable = false
+	require.Equal(t, 0, sampled())
+}
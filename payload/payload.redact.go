@@ -0,0 +1,216 @@
+package payload
+
+import (
+	stdjson "encoding/json"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	commondef "github.com/eden-quan/go-biz-kit/common/def"
+)
+
+// MaskedValue 脱敏后记录的值
+const MaskedValue = "******"
+
+// DefaultRedactFields 未配置 redact_fields 时默认脱敏的字段
+var DefaultRedactFields = []string{"password", "*token", "secret*", "id_card"}
+
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// 消息类型是否包含标记了 sensitive 或 redact 的字段, key 为 protoreflect.FullName
+var annotatedTypes sync.Map
+
+// Redact 将 v 序列化为 JSON 并对敏感字段脱敏, fields 为需要脱敏的字段名规则,
+// proto 消息中标记了 sensitive 或 redact 的字段总是会被脱敏, []byte 为 JSON 时按字段脱敏,
+// 否则无法判断其中是否包含敏感内容, 只返回 <N bytes>
+func Redact(v interface{}, fields []string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch m := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		if !stdjson.Valid(m) {
+			return []byte("<" + strconv.Itoa(len(m)) + " bytes>"), nil
+		}
+		data = m
+	case proto.Message:
+		data, err = marshalOptions.Marshal(redactMessage(m))
+	default:
+		data, err = stdjson.Marshal(m)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := normalizePatterns(fields)
+	if len(patterns) == 0 {
+		return data, nil
+	}
+
+	var tree interface{}
+	if err := stdjson.Unmarshal(data, &tree); err != nil {
+		return data, nil
+	}
+
+	if !redactTree(tree, patterns) {
+		return data, nil
+	}
+
+	return stdjson.Marshal(tree)
+}
+
+// redactTree 将匹配规则的字段替换为 MaskedValue, 返回是否有字段被替换
+func redactTree(node interface{}, patterns []string) bool {
+	changed := false
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, value := range n {
+			if matchField(key, patterns) {
+				n[key] = MaskedValue
+				changed = true
+				continue
+			}
+			changed = redactTree(value, patterns) || changed
+		}
+	case []interface{}:
+		for _, value := range n {
+			changed = redactTree(value, patterns) || changed
+		}
+	}
+
+	return changed
+}
+
+func normalizePatterns(fields []string) []string {
+	if len(fields) == 0 {
+		fields = DefaultRedactFields
+	}
+
+	patterns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = normalizeField(field); field != "" {
+			patterns = append(patterns, field)
+		}
+	}
+
+	return patterns
+}
+
+// normalizeField 忽略大小写、下划线及中划线, 使 id_card、idCard 及 ID-Card 可以使用同一个规则匹配
+func normalizeField(field string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(field)))
+}
+
+func matchField(key string, patterns []string) bool {
+	key = normalizeField(key)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactMessage 返回标记了 sensitive 或 redact 的字段被脱敏的副本, 字符串字段替换为 MaskedValue, 其他类型的字段被清空
+func redactMessage(msg proto.Message) proto.Message {
+	desc := msg.ProtoReflect().Descriptor()
+	annotated, ok := annotatedTypes.Load(desc.FullName())
+	if !ok {
+		annotated = hasAnnotated(desc, map[protoreflect.FullName]bool{})
+		annotatedTypes.Store(desc.FullName(), annotated)
+	}
+
+	if !annotated.(bool) {
+		return msg
+	}
+
+	cloned := proto.Clone(msg)
+	redactReflect(cloned.ProtoReflect())
+
+	return cloned
+}
+
+func redactReflect(msg protoreflect.Message) {
+	// Range 期间修改消息的行为未定义, 因此先收集字段
+	fields := make([]protoreflect.FieldDescriptor, 0)
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		value := msg.Get(fd)
+
+		switch {
+		case isAnnotated(fd) && fd.Kind() == protoreflect.StringKind && fd.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				list.Set(i, protoreflect.ValueOfString(MaskedValue))
+			}
+		case isAnnotated(fd) && fd.Kind() == protoreflect.StringKind && !fd.IsMap():
+			msg.Set(fd, protoreflect.ValueOfString(MaskedValue))
+		case isAnnotated(fd):
+			msg.Clear(fd)
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					redactReflect(v.Message())
+					return true
+				})
+			}
+		case fd.Message() != nil && fd.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				redactReflect(list.Get(i).Message())
+			}
+		case fd.Message() != nil:
+			redactReflect(value.Message())
+		}
+	}
+}
+
+func hasAnnotated(desc protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) bool {
+	if visited[desc.FullName()] {
+		return false
+	}
+	visited[desc.FullName()] = true
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if isAnnotated(fd) {
+			return true
+		}
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		if fd.Message() != nil && hasAnnotated(fd.Message(), visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAnnotated(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return false
+	}
+
+	sensitive, _ := proto.GetExtension(opts, commondef.E_Sensitive).(bool)
+	redact, _ := proto.GetExtension(opts, commondef.E_Redact).(bool)
+	return sensitive || redact
+}
@@ -0,0 +1,36 @@
+package payload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// go test -v -count=1 ./payload -test.run=TestRedact
+func TestRedact(t *testing.T) {
+	data, err := Redact(&def.Redis{Username: "eden", Password: "123456"}, nil)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"username":"eden"`)
+	require.Contains(t, string(data), `"password":"`+MaskedValue+`"`)
+
+	data, err = Redact([]byte(`{"user":{"idCard":"110","accessToken":"t"},"items":[{"Secret_Key":"k"}]}`), nil)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "110")
+	require.NotContains(t, string(data), `"t"`)
+	require.NotContains(t, string(data), `"k"`)
+
+	data, err = Redact(map[string]string{"phone": "138", "name": "eden"}, []string{"phone"})
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"eden","phone":"`+MaskedValue+`"}`, string(data))
+
+	// 非 JSON 的内容无法脱敏, 只记录长度
+	data, err = Redact([]byte("password=123456"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "<15 bytes>", string(data))
+
+	conf := &def.Log_Payload{MaxSize: 8}
+	require.True(t, strings.HasPrefix(format([]byte("[1,2,3,4,5]"), conf), "[1,2,3,4...(truncated 3 bytes)"))
+}
@@ -2,7 +2,9 @@ package injection
 
 import (
 	"github.com/eden-quan/go-biz-kit/injection"
+	middlewareutil "github.com/eden-quan/go-biz-kit/middleware"
 	servers "github.com/eden-quan/go-biz-kit/server"
+	"github.com/eden-quan/go-biz-kit/signature"
 )
 
 /*
@@ -11,6 +13,15 @@ Inject 为微服务框架注入 Web/GRPC 的基础支持
  2. NewHTTPServer 依赖配置中心及日志库，为开发框架提供了 HTTP 基础支持，可通过 *http.Server 获取
  3. NewApp 根据配置中心信息，提供 GRPC 及 HTTP 服务，开发框架当前基于 Kratos 提供微服务基础能力，
     后续可通过替换该组件提供其他微服务框架作为底层支撑
+ 4. NewReadiness 提供服务的就绪状态，服务退出时会先将其置为不可用再停止服务, NewStopTimeout 根据退出配置延长容器的退出超时时间
+ 5. NewRateLimit 为服务提供限流能力，限流规则通过配置中心 /middleware/ratelimit/config 配置
+ 6. signature.NewServer 根据配置中心 /middleware/signature/config 校验服务间请求的签名
+ 7. NewJWTAuth 为服务提供 JWT 鉴权能力，鉴权配置通过配置中心 /middleware/jwt/config 配置
+ 8. NewDecrypt 在 JWT 鉴权之后使用 /basic/secret 中 transfer_encrypt 的私钥解密请求中的敏感字段
+ 9. NewPolicy 在敏感字段解密之后根据配置中心 /middleware/policy/config 中的策略校验接口权限
+ 10. NewIdempotency 在业务处理前按 Idempotency-Key 使用 Redis 对 /middleware/idempotency/config 中配置的接口做幂等处理
+ 11. StartAdminServer 在启用 admin 时使用独立的地址提供 pprof、路由列表、依赖图、运行信息及监控指标
+ 12. StartMetricsServer 在配置了 metrics.address 时使用独立的地址提供监控指标, 监控指标不会挂载到业务 HTTP 服务上
 */
 func Inject() {
 	InjectIns(injection.GlobalInjector())
@@ -22,6 +33,15 @@ InjectIns 使用创建实例的方式为微服务框架注入 Web/GRPC 的基础
  2. NewHTTPServer 依赖配置中心及日志库，为开发框架提供了 HTTP 基础支持，可通过 *http.Server 获取
  3. NewApp 根据配置中心信息，提供 GRPC 及 HTTP 服务，开发框架当前基于 Kratos 提供微服务基础能力，
     后续可通过替换该组件提供其他微服务框架作为底层支撑
+ 4. NewReadiness 提供服务的就绪状态，服务退出时会先将其置为不可用再停止服务, NewStopTimeout 根据退出配置延长容器的退出超时时间
+ 5. NewRateLimit 为服务提供限流能力，限流规则通过配置中心 /middleware/ratelimit/config 配置
+ 6. signature.NewServer 根据配置中心 /middleware/signature/config 校验服务间请求的签名
+ 7. NewJWTAuth 为服务提供 JWT 鉴权能力，鉴权配置通过配置中心 /middleware/jwt/config 配置
+ 8. NewDecrypt 在 JWT 鉴权之后使用 /basic/secret 中 transfer_encrypt 的私钥解密请求中的敏感字段
+ 9. NewPolicy 在敏感字段解密之后根据配置中心 /middleware/policy/config 中的策略校验接口权限
+ 10. NewIdempotency 在业务处理前按 Idempotency-Key 使用 Redis 对 /middleware/idempotency/config 中配置的接口做幂等处理
+ 11. StartAdminServer 在启用 admin 时使用独立的地址提供 pprof、路由列表、依赖图、运行信息及监控指标
+ 12. StartMetricsServer 在配置了 metrics.address 时使用独立的地址提供监控指标, 监控指标不会挂载到业务 HTTP 服务上
 */
 func InjectIns(inj *injection.Injector) {
 	inj.InjectMany(
@@ -29,9 +49,39 @@ func InjectIns(inj *injection.Injector) {
 		servers.NewGrpcServiceRegistrar,
 		servers.NewHTTPServer,
 		servers.NewApp,
+		servers.NewReadiness,
+		servers.NewStopTimeout,
 	)
 
+	// 限流在鉴权之前执行, 规则通过配置中心 /middleware/ratelimit/config 配置
+	inj.InjectMiddleware(middlewareutil.NewRateLimit, injection.WithPhase(injection.PhaseBeforeAuth))
+	// 服务间请求签名在用户鉴权之前校验, 未启用时直接放行
+	inj.InjectMiddleware(signature.NewServer, injection.WithPhase(injection.PhaseBeforeAuth))
+	// JWT 鉴权在读取 Authorization 之后执行, 未启用时直接放行
+	inj.InjectMiddleware(middlewareutil.NewJWTAuth, injection.WithPhase(injection.PhaseAfterAuth))
+	// 敏感字段在权限校验、参数校验及日志之前解密, 权限条件与明文比较, 日志中间件只会得到脱敏后的请求
+	inj.InjectMiddleware(middlewareutil.NewDecrypt, injection.WithPhase(injection.PhaseAfterAuth))
+	// 权限校验依赖 JWT 鉴权写入的用户信息及解密后的请求, 因此在其之后注册
+	inj.InjectMiddleware(middlewareutil.NewPolicy, injection.WithPhase(injection.PhaseAfterAuth))
+	// 幂等处理在参数校验之后执行, 校验失败的请求不会占用 Idempotency-Key
+	inj.InjectMiddleware(middlewareutil.NewIdempotency, injection.WithPhase(injection.PhaseBeforeHandler))
+
 	inj.Invoke(
 		injection.WithInvoke(servers.StartKratosApp),
 	)
+
+	// 管理端点使用独立的地址, 未启用时不会监听
+	inj.Invoke(
+		injection.WithInvoke(servers.StartAdminServer),
+	)
+
+	// 监控指标默认由管理端点提供, 配置了 metrics.address 时使用独立的地址
+	inj.Invoke(
+		injection.WithInvoke(servers.StartMetricsServer),
+	)
+}
+
+// Module 服务模块 server，提供 GRPC / HTTP 服务及 Kratos APP, 并在启动时运行 APP
+func Module() *injection.Module {
+	return injection.NewModule("server").Require("config", "logger", "sqlaction").With(InjectIns)
 }
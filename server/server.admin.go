@@ -0,0 +1,208 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"go.uber.org/fx"
+
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+// 管理端点默认的监听地址
+const defaultAdminAddress = "127.0.0.1:9901"
+
+// AdminParam 为启动管理端点所需的依赖
+type AdminParam struct {
+	fx.In
+
+	Lifecycle     fx.Lifecycle
+	Logger        log.Logger
+	Configuration *def.Configuration
+	LocalConfig   *config.LocalConfigure
+	Graph         fx.DotGraph
+	Services      *injection.RegisteredServices `optional:"true"`
+	GRPCServer    *grpc.Server                  `optional:"true"`
+	HTTPServer    *khttp.Server                 `optional:"true"`
+}
+
+/*
+StartAdminServer 在 /basic/config 中启用了 admin 时, 使用独立的地址启动管理端点, 提供以下接口:
+ 1. /debug/pprof/ 按需采集 CPU、内存、协程等性能数据, 如 go tool pprof http://127.0.0.1:9901/debug/pprof/profile?seconds=30
+ 2. /routes 已注册的 HTTP / GRPC 服务, HTTP 路由及 GRPC 接口
+ 3. /graph 依赖注入容器的 DOT 依赖图, 可通过 dot -Tsvg 转换为图片
+ 4. /info 应用的名称、版本、环境及编译信息
+ 5. /metrics 未配置 metrics.address 时提供 Prometheus 监控指标, 路径可通过 metrics.path 修改
+*/
+func StartAdminServer(p AdminParam) {
+	conf := p.Configuration.Server.GetAdmin()
+	if !conf.GetEnable() {
+		return
+	}
+
+	address := conf.GetAddress()
+	if address == "" {
+		address = defaultAdminAddress
+	}
+
+	helper := log.NewHelper(log.With(p.Logger, "module", "admin"))
+	serveHTTP(p.Lifecycle, helper, "admin", address, adminMux(p, time.Now()))
+}
+
+// adminMux 返回管理端点的路由
+func adminMux(p AdminParam, startAt time.Time) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/routes", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, adminRoutes(p))
+	})
+	mux.HandleFunc("/graph", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		_, _ = w.Write([]byte(p.Graph))
+	})
+	mux.HandleFunc("/info", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, adminInfo(p.LocalConfig, startAt))
+	})
+
+	// 配置了独立地址时由 StartMetricsServer 提供监控指标
+	if p.Configuration.Metrics.GetAddress() == "" {
+		mountMetrics(mux, p.Configuration)
+	}
+
+	return mux
+}
+
+// serveHTTP 在应用启动时监听 address 并提供 handler, 应用停止时关闭
+func serveHTTP(lc fx.Lifecycle, helper *log.Helper, name string, address string, handler http.Handler) {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: time.Second * 10,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			listener, err := net.Listen("tcp", address)
+			if err != nil {
+				return err
+			}
+
+			helper.Infof("%s server listening on %s", name, listener.Addr())
+			go func() {
+				if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					helper.Errorw("msg", name+" server stopped with error", "err", err)
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return srv.Shutdown(ctx)
+		},
+	})
+}
+
+type adminRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+type adminRouteList struct {
+	HTTPServices []string     `json:"http_services"`
+	GRPCServices []string     `json:"grpc_services"`
+	HTTPRoutes   []adminRoute `json:"http_routes"`
+	GRPCMethods  []string     `json:"grpc_methods"`
+}
+
+// adminRoutes 返回已注册的服务及路由
+func adminRoutes(p AdminParam) *adminRouteList {
+	routes := &adminRouteList{
+		HTTPRoutes:  make([]adminRoute, 0),
+		GRPCMethods: make([]string, 0),
+	}
+
+	if p.Services != nil {
+		routes.HTTPServices = p.Services.HTTP
+		routes.GRPCServices = p.Services.GRPC
+	}
+
+	if p.HTTPServer != nil {
+		_ = p.HTTPServer.WalkRoute(func(info khttp.RouteInfo) error {
+			routes.HTTPRoutes = append(routes.HTTPRoutes, adminRoute{Method: info.Method, Path: info.Path})
+			return nil
+		})
+	}
+
+	if p.GRPCServer != nil {
+		for name, info := range p.GRPCServer.GetServiceInfo() {
+			for _, method := range info.Methods {
+				routes.GRPCMethods = append(routes.GRPCMethods, "/"+name+"/"+method.Name)
+			}
+		}
+		sort.Strings(routes.GRPCMethods)
+	}
+
+	return routes
+}
+
+type adminBuildInfo struct {
+	Name      string            `json:"name"`
+	Version   string            `json:"version"`
+	Env       string            `json:"env"`
+	GoVersion string            `json:"go_version"`
+	Module    string            `json:"module"`
+	Settings  map[string]string `json:"settings"` // 编译信息, 如 vcs.revision, vcs.time
+	Hostname  string            `json:"hostname"`
+	Pid       int               `json:"pid"`
+	StartAt   time.Time         `json:"start_at"`
+	Uptime    string            `json:"uptime"`
+}
+
+// adminInfo 返回应用及编译信息
+func adminInfo(local *config.LocalConfigure, startAt time.Time) *adminBuildInfo {
+	hostname, _ := os.Hostname()
+	info := &adminBuildInfo{
+		Name:      local.APP.Name,
+		Version:   local.APP.Version,
+		Env:       local.APP.Env,
+		GoVersion: runtime.Version(),
+		Settings:  make(map[string]string),
+		Hostname:  hostname,
+		Pid:       os.Getpid(),
+		StartAt:   startAt,
+		Uptime:    time.Since(startAt).Round(time.Second).String(),
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.Module = build.Main.Path + "@" + build.Main.Version
+		for _, setting := range build.Settings {
+			info.Settings[setting.Key] = setting.Value
+		}
+	}
+
+	return info
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
@@ -3,19 +3,46 @@ package servers
 import (
 	"context"
 	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
 	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	etcd "go.etcd.io/etcd/client/v3"
 	"go.uber.org/fx"
 
 	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/discovery"
+	"github.com/eden-quan/go-biz-kit/injection"
+	"github.com/eden-quan/go-biz-kit/setup"
 )
 
-// NewApp 通过配置信息提供 Kratos 的 APP 示例，以及对应的 Server (http/grpc), 供后续的实现
-func NewApp(localConf *config.LocalConfigure, gs *grpc.Server, hs *http.Server, logger log.Logger) (*kratos.App, error) {
+const (
+	// 等待处理中请求完成的默认时间
+	defaultShutdownTimeout = time.Second * 30
+	// 关闭资源等收尾操作预留的时间
+	shutdownCleanupMargin = time.Second * 10
+)
+
+// NewApp 通过配置信息提供 Kratos 的 APP 示例，以及对应的 Server (http/grpc), 供后续的实现,
+// APP 停止时会先将 readiness 置为不可用并等待 /basic/config 中 shutdown 配置的 drain_delay, 再停止接收新的请求,
+// 启用了 discovery 时 APP 启动后会将 HTTP / GRPC 服务的实际地址以 app.name 为服务名注册到 ETCD, 停止时先于 drain_delay 注销
+func NewApp(
+	localConf *config.LocalConfigure,
+	conf *def.Configuration,
+	gs *grpc.Server,
+	hs *http.Server,
+	logger log.Logger,
+	readiness *Readiness,
+	etcdClient *etcd.Client,
+) (*kratos.App, error) {
 
 	servers := make([]transport.Server, 0)
 
@@ -26,32 +53,194 @@ func NewApp(localConf *config.LocalConfigure, gs *grpc.Server, hs *http.Server,
 		servers = append(servers, hs)
 	}
 
+	var registrar registry.Registrar
+	if conf.Server.GetDiscovery().GetEnable() {
+		if etcdClient == nil {
+			return nil, discovery.ErrClientRequired
+		}
+		registrar = discovery.NewEtcdRegistry(etcdClient, conf.Server.GetDiscovery(), logger)
+	}
+
 	appOptions := []kratos.Option{
 		kratos.ID(localConf.APP.Name),
 		kratos.Name(localConf.APP.Name),
 		kratos.Logger(logger),
 		kratos.Server(servers...),
+		kratos.StopTimeout(shutdownTimeout(conf)),
+		kratos.BeforeStop(drainBeforeStop(conf, readiness, registrar, logger)),
+	}
+
+	if registrar != nil {
+		// 同一服务的多个实例需要使用不同的 ID 注册
+		appOptions = append(appOptions,
+			kratos.ID(instanceID(localConf)),
+			kratos.Version(localConf.APP.Version),
+			kratos.Registrar(registrar),
+		)
 	}
 
 	app := kratos.New(appOptions...)
 	return app, nil
 }
 
-func StartKratosApp(lifecycle fx.Lifecycle, app *kratos.App, logger log.Logger) {
-	lifecycle.Append(fx.Hook{
+// NewStopTimeout 根据退出配置提供依赖注入容器的退出超时时间, 确保容器有足够的时间完成连接排空及资源的关闭
+func NewStopTimeout(conf *def.Configuration) injection.StopTimeout {
+	return injection.StopTimeout(drainDelay(conf) + shutdownTimeout(conf) + shutdownCleanupMargin)
+}
+
+// StartAppParam 为启动 Kratos APP 所需的依赖
+type StartAppParam struct {
+	fx.In
+
+	Lifecycle     fx.Lifecycle
+	Shutdowner    fx.Shutdowner
+	App           *kratos.App
+	Logger        log.Logger
+	Configuration *def.Configuration
+	GRPCServer    *grpc.Server   `optional:"true"`
+	HTTPServer    *http.Server   `optional:"true"`
+	Closers       *setup.Closers `optional:"true"`
+}
+
+/*
+StartKratosApp 在启动时运行 Kratos APP, APP 运行失败时会通知容器以错误码 1 退出, 服务退出时按照以下顺序进行:
+ 1. 将 Readiness 置为不可用, 使负载均衡停止转发新的请求
+ 2. 等待 drain_delay, 确保负载均衡已感知到服务不可用
+ 3. 停止 HTTP / GRPC 服务，不再接收新的请求
+ 4. 等待处理中的请求完成，超过 timeout 后强制关闭服务
+ 5. 依次关闭消息队列、数据库连接池及链路跟踪等资源, 具体见 setup.Closers
+*/
+func StartKratosApp(p StartAppParam) {
+	helper := log.NewHelper(log.With(p.Logger, "module", "shutdown"))
+	done := make(chan struct{})
+	stopping := atomic.Bool{}
+
+	p.Lifecycle.Append(fx.Hook{
 		OnStart: func(_ context.Context) error {
 			go func() {
-				if err := app.Run(); err != nil {
-					err := fmt.Errorf("app.Run %+v\n", err)
-					panic(err)
+				defer close(done)
+
+				err := p.App.Run()
+				if stopping.Load() {
+					if err != nil {
+						helper.Errorw("msg", "app stopped with error", "err", err)
+					}
+					return
+				}
+
+				// APP 异常退出或通过信号自行退出时，通知容器退出以关闭其他资源
+				if err != nil {
+					helper.Errorw("msg", "app run failed, shutting down", "err", err)
+					_ = p.Shutdowner.Shutdown(fx.ExitCode(1))
+					return
 				}
 
+				helper.Info("app stopped, shutting down")
+				_ = p.Shutdowner.Shutdown()
 			}()
 
 			return nil
 		},
-		OnStop: func(_ context.Context) error {
-			return app.Stop()
+		OnStop: func(ctx context.Context) error {
+			stopping.Store(true)
+
+			// 1 - 3: 置为不可用并等待 drain_delay 后停止服务, 见 drainBeforeStop
+			err := p.App.Stop()
+			if err != nil {
+				helper.Errorw("msg", "stop app failed", "err", err)
+			}
+
+			// 4: 等待处理中的请求完成
+			timeout := shutdownTimeout(p.Configuration)
+			helper.Infow("msg", "waiting for in-flight requests", "timeout", timeout.String())
+			start := time.Now()
+
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+
+			select {
+			case <-done:
+				helper.Infow("msg", "in-flight requests completed", "runtime", time.Since(start).String())
+			case <-timer.C:
+				helper.Warnw("msg", "in-flight requests not completed before timeout, force closing servers", "timeout", timeout.String())
+				forceClose(p.GRPCServer, p.HTTPServer)
+			case <-ctx.Done():
+				helper.Warnw("msg", "shutdown context done before in-flight requests completed, force closing servers", "err", ctx.Err())
+				forceClose(p.GRPCServer, p.HTTPServer)
+			}
+
+			// 5: 关闭资源
+			if p.Closers != nil {
+				helper.Info("closing resources")
+				if closeErr := p.Closers.Close(ctx); closeErr != nil && err == nil {
+					err = closeErr
+				}
+			}
+
+			helper.Info("shutdown completed")
+			return err
 		},
 	})
 }
+
+// drainBeforeStop 返回 APP 停止前执行的排空函数, 该函数将 readiness 置为不可用并从注册中心注销后等待 drain_delay,
+// 容器退出及 Kratos 自身接收到退出信号时都会触发 APP 的停止，因此只会执行一次
+func drainBeforeStop(conf *def.Configuration, readiness *Readiness, registrar registry.Registrar, logger log.Logger) func(ctx context.Context) error {
+	helper := log.NewHelper(log.With(logger, "module", "shutdown"))
+	once := sync.Once{}
+
+	return func(ctx context.Context) error {
+		once.Do(func() {
+			readiness.SetReady(false)
+			helper.Info("readiness set to not ready")
+
+			// Kratos 在 drain_delay 之后才会注销, 提前注销使通过服务发现调用的客户端在等待期间即可摘除该实例
+			if info, ok := kratos.FromContext(ctx); ok && registrar != nil {
+				instance := &registry.ServiceInstance{ID: info.ID(), Name: info.Name()}
+				if err := registrar.Deregister(ctx, instance); err != nil {
+					helper.Warnw("msg", "deregister from discovery failed", "err", err)
+				} else {
+					helper.Info("deregistered from discovery")
+				}
+			}
+
+			delay := drainDelay(conf)
+			if delay > 0 {
+				helper.Infow("msg", "waiting for load balancer to observe readiness", "drain_delay", delay.String())
+				time.Sleep(delay)
+			}
+
+			helper.Info("stop accepting new requests")
+		})
+
+		return nil
+	}
+}
+
+// forceClose 强制关闭服务，中断所有处理中的请求
+func forceClose(gs *grpc.Server, hs *http.Server) {
+	if gs != nil {
+		gs.Server.Stop()
+	}
+	if hs != nil {
+		_ = hs.Close()
+	}
+}
+
+// instanceID 返回服务实例的唯一标识, 由服务名、主机名及进程号组成
+func instanceID(localConf *config.LocalConfigure) string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%s-%d", localConf.APP.Name, hostname, os.Getpid())
+}
+
+func drainDelay(conf *def.Configuration) time.Duration {
+	return conf.Server.GetShutdown().GetDrainDelay().AsDuration()
+}
+
+func shutdownTimeout(conf *def.Configuration) time.Duration {
+	if conf.Server.GetShutdown().GetTimeout() == nil {
+		return defaultShutdownTimeout
+	}
+
+	return conf.Server.GetShutdown().GetTimeout().AsDuration()
+}
@@ -8,17 +8,27 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	etcd "go.etcd.io/etcd/client/v3"
 
 	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
 )
 
 var _app *kratos.App = nil
 var _once sync.Once
 
-func NewSingleApp(localConf *config.LocalConfigure, gs *grpc.Server, hs *http.Server, logger log.Logger) (*kratos.App, error) {
+func NewSingleApp(
+	localConf *config.LocalConfigure,
+	conf *def.Configuration,
+	gs *grpc.Server,
+	hs *http.Server,
+	logger log.Logger,
+	readiness *Readiness,
+	etcdClient *etcd.Client,
+) (*kratos.App, error) {
 	_once.Do(func() {
 		var err error
-		_app, err = NewApp(localConf, gs, hs, logger)
+		_app, err = NewApp(localConf, conf, gs, hs, logger, readiness, etcdClient)
 		if err != nil {
 			panic(fmt.Sprintf("create application failed with error %s", err))
 		}
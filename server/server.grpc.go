@@ -2,15 +2,18 @@ package servers
 
 import (
 	middlewareutil "github.com/eden-quan/go-biz-kit/middleware"
-	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	grpc2 "google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/eden-quan/go-biz-kit/config/def"
 	"github.com/eden-quan/go-biz-kit/injection"
+	"github.com/eden-quan/go-biz-kit/payload"
 	setup2 "github.com/eden-quan/go-biz-kit/setup"
+	tlsutil "github.com/eden-quan/go-biz-kit/tls"
 
 	apppkg "github.com/eden-quan/go-kratos-pkg/app"
 )
@@ -22,6 +25,7 @@ func NewGRPCServer(
 	logger log.Logger,
 	customMiddlewares *injection.MiddlewareCollector,
 	actionManage *setup2.ActionManager,
+	readiness *Readiness,
 ) (srv *grpc.Server, err error) {
 	helper := log.NewHelper(logger)
 
@@ -43,32 +47,32 @@ func NewGRPCServer(
 		opts = append(opts, grpc.Address(grpcConfig.GetAddress()))
 	}
 
-	// 默认超时时间 5 分钟
-	timeOut := time.Minute * 5
-	if grpcConfig.GetTimeout() != nil {
-		timeOut = grpcConfig.GetTimeout().AsDuration()
+	// 超时时间由 Timeout 中间件按接口控制, 具体见 def.Registry.OperationTimeout
+	opts = append(opts, grpc.Timeout(0))
+
+	// TLS 的开关需要重启服务, 证书及 CA 的变化在握手时生效
+	tlsConf, err := tlsutil.ServerConfig(func() *def.TLS { return configure.Server.GetGrpc().GetTls() }, logger, "h2")
+	if err != nil {
+		return nil, err
+	}
+	if tlsConf != nil {
+		opts = append(opts, grpc.TLSConfig(tlsConf))
 	}
 
-	opts = append(opts, grpc.Timeout(timeOut))
+	// 使用 Readiness 提供健康检查, 以便在退出时先于停止服务将状态置为不可用
+	opts = append(opts, grpc.CustomHealth())
 
-	var middlewareSlice = DefaultGrpcServerMiddlewares()
 	middleLogger, err := manager.LoggerMiddleware()
 	if err != nil {
 		return srv, err
 	}
 
-	if customMiddlewares != nil && customMiddlewares.Middlewares != nil {
-		// 批量添加自定义GRPC中间件
-		for _, middle := range customMiddlewares.Middlewares {
-			if middle.MidType == "GRPC" {
-				middlewareSlice = append(middlewareSlice, middle.Middleware)
-			}
-		}
-	}
-
-	// 日志输出, SQL Action 处理器，确保在真正的业务逻辑执行之前触发
-	middlewareSlice = append(middlewareSlice,
-		apppkg.ServerLog(middleLogger),
+	middlewareSlice := ServerMiddlewares(
+		injection.MiddlewareTypeGRPC,
+		customMiddlewares,
+		middlewareutil.LoadShedding(injection.MiddlewareTypeGRPC, func() *def.LoadShedding { return configure.Server.GetLoadShedding() }, logger),
+		middlewareutil.Timeout(func() *def.Registry { return configure.Server.GetGrpc() }),
+		middleware.Chain(apppkg.ServerLog(middleLogger), payload.Server(middleLogger)),
 		middlewareutil.SQLActionMiddleware(actionManage),
 	)
 
@@ -77,6 +81,7 @@ func NewGRPCServer(
 
 	// 服务
 	srv = grpc.NewServer(opts...)
+	grpc_health_v1.RegisterHealthServer(srv.Server, readiness.HealthServer())
 
 	return srv, err
 }
@@ -2,14 +2,17 @@ package servers
 
 import (
 	middlewareutil "github.com/eden-quan/go-biz-kit/middleware"
-	"time"
 
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport/http"
 
 	apputil "github.com/eden-quan/go-biz-kit/app"
 	"github.com/eden-quan/go-biz-kit/config/def"
 	"github.com/eden-quan/go-biz-kit/injection"
+	"github.com/eden-quan/go-biz-kit/payload"
 	setup2 "github.com/eden-quan/go-biz-kit/setup"
+	tlsutil "github.com/eden-quan/go-biz-kit/tls"
 
 	apppkg "github.com/eden-quan/go-kratos-pkg/app"
 )
@@ -18,8 +21,10 @@ import (
 func NewHTTPServer(
 	configuration *def.Configuration,
 	manager *setup2.LoggerManager,
+	logger log.Logger,
 	customMiddlewares *injection.MiddlewareCollector,
 	actionManage *setup2.ActionManager,
+	readiness *Readiness,
 ) (*http.Server, error) {
 
 	if !configuration.Server.GetHttp().GetEnable() {
@@ -34,44 +39,44 @@ func NewHTTPServer(
 		opts = append(opts, http.Address(httpConfig.GetAddress()))
 	}
 
-	// 默认超时 5 分钟
-	timeOut := time.Minute * 5
-	if httpConfig.GetTimeout() != nil {
-		timeOut = httpConfig.GetTimeout().AsDuration()
+	// 超时时间由 Timeout 中间件按接口控制, 具体见 def.Registry.OperationTimeout
+	opts = append(opts, http.Timeout(0))
+
+	// TLS 的开关需要重启服务, 证书及 CA 的变化在握手时生效
+	tlsConf, err := tlsutil.ServerConfig(func() *def.TLS { return configuration.Server.GetHttp().GetTls() }, logger, "h2", "http/1.1")
+	if err != nil {
+		return nil, err
+	}
+	if tlsConf != nil {
+		opts = append(opts, http.TLSConfig(tlsConf))
 	}
-	opts = append(opts, http.Timeout(timeOut))
 
 	// 响应
 	opts = append(opts, http.RequestDecoder(apputil.RequestDecoder))
 	opts = append(opts, http.ErrorEncoder(apputil.ErrorEncoder))
 
-	var middlewareSlice = DefaultServerMiddlewares()
 	middleLogger, err := manager.LoggerMiddleware()
-
 	if err != nil {
 		return nil, err
 	}
 
-	// 日志输出, SQL Action 处理器，确保在真正的业务逻辑执行之前触发
-	middlewareSlice = append(middlewareSlice,
-		apppkg.ServerLog(middleLogger),
+	middlewareSlice := ServerMiddlewares(
+		injection.MiddlewareTypeHTTP,
+		customMiddlewares,
+		middlewareutil.LoadShedding(injection.MiddlewareTypeHTTP, func() *def.LoadShedding { return configuration.Server.GetLoadShedding() }, logger),
+		middlewareutil.Timeout(func() *def.Registry { return configuration.Server.GetHttp() }),
+		middleware.Chain(apppkg.ServerLog(middleLogger), payload.Server(middleLogger)),
 		middlewareutil.SQLActionMiddleware(actionManage),
 	)
 
-	if customMiddlewares != nil && customMiddlewares.Middlewares != nil {
-		// 批量添加自定义HTTP中间件
-		for _, middle := range customMiddlewares.Middlewares {
-			if middle.MidType == "HTTP" {
-				middlewareSlice = append(middlewareSlice, middle.Middleware)
-			}
-		}
-	}
-
 	// 中间件选项
 	opts = append(opts, http.Middleware(middlewareSlice...))
 
 	// 服务
 	srv := http.NewServer(opts...)
 
+	// 就绪检查
+	srv.Handle(readinessPath(configuration), readiness)
+
 	return srv, err
 }
@@ -0,0 +1,45 @@
+package servers
+
+import (
+	"net/http"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.uber.org/fx"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/metrics"
+)
+
+// MetricsParam 为启动监控指标端点所需的依赖
+type MetricsParam struct {
+	fx.In
+
+	Lifecycle     fx.Lifecycle
+	Logger        log.Logger
+	Configuration *def.Configuration
+}
+
+/*
+StartMetricsServer 在 /basic/config 中配置了 metrics.address 时, 使用独立的地址提供监控指标,
+未配置时监控指标由 admin 管理端点提供, 两者都不会挂载到业务 HTTP 服务上, 仅启用 GRPC 的服务同样可以采集
+*/
+func StartMetricsServer(p MetricsParam) {
+	conf := &p.Configuration.Metrics
+	helper := log.NewHelper(log.With(p.Logger, "module", "metrics"))
+
+	if conf.GetAddress() == "" {
+		if conf.GetEnable() && !p.Configuration.Server.GetAdmin().GetEnable() {
+			helper.Warn("metrics is enabled but neither metrics.address nor admin is configured, metrics will not be exposed")
+		}
+		return
+	}
+
+	mux := http.NewServeMux()
+	mountMetrics(mux, p.Configuration)
+	serveHTTP(p.Lifecycle, helper, "metrics", conf.GetAddress(), mux)
+}
+
+// mountMetrics 挂载监控指标, 包含 HTTP 及 GRPC 服务的指标, 配置在每次请求时读取, 支持动态开关
+func mountMetrics(mux *http.ServeMux, configuration *def.Configuration) {
+	mux.Handle(metrics.Path(&configuration.Metrics), metrics.Handler(func() *def.Metrics { return &configuration.Metrics }))
+}
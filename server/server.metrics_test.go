@@ -0,0 +1,40 @@
+package servers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// go test -v -count=1 ./server -test.run=TestAdminMetrics
+func TestAdminMetrics(t *testing.T) {
+	configuration := &def.Configuration{Metrics: def.Metrics{Enable: true}}
+	p := AdminParam{Configuration: configuration, LocalConfig: &config.LocalConfigure{}}
+	status := func(mux *http.ServeMux, path string) int {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	// 未配置独立地址时由管理端点提供, 配置在每次请求时读取
+	mux := adminMux(p, time.Now())
+	require.Equal(t, http.StatusOK, status(mux, "/metrics"))
+	configuration.Metrics.Enable = false
+	require.Equal(t, http.StatusNotFound, status(mux, "/metrics"))
+
+	configuration.Metrics = def.Metrics{Enable: true, Path: "/prometheus"}
+	mux = adminMux(p, time.Now())
+	require.Equal(t, http.StatusOK, status(mux, "/prometheus"))
+
+	// 配置了独立地址时管理端点不再提供
+	configuration.Metrics.Address = "127.0.0.1:9902"
+	mux = adminMux(p, time.Now())
+	require.Equal(t, http.StatusNotFound, status(mux, "/prometheus"))
+	require.Equal(t, http.StatusOK, status(mux, "/info"))
+}
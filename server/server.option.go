@@ -11,6 +11,8 @@ import (
 	"github.com/go-kratos/kratos/v2/transport"
 
 	errorutil "github.com/eden-quan/go-biz-kit/error"
+	"github.com/eden-quan/go-biz-kit/injection"
+	"github.com/eden-quan/go-biz-kit/metrics"
 	middlewareutil "github.com/eden-quan/go-biz-kit/middleware"
 	"github.com/eden-quan/go-biz-kit/tracing"
 )
@@ -48,14 +50,46 @@ func DefaultServerMiddlewares() []middleware.Middleware {
 	}
 }
 
+// DefaultGrpcServerMiddlewares GRPC 中间件
+// Deprecated: HTTP 与 GRPC 已统一使用 ServerMiddlewares 组装调用链
 func DefaultGrpcServerMiddlewares() []middleware.Middleware {
-	return []middleware.Middleware{
+	return DefaultServerMiddlewares()
+}
+
+// ServerMiddlewares 按照统一的顺序为 kind (injection.MiddlewareTypeHTTP / injection.MiddlewareTypeGRPC) 组装服务端中间件,
+// HTTP 与 GRPC 使用相同的调用链, 自定义中间件根据注册时指定的阶段插入到对应的位置:
+// recovery -> metadata -> tracing -> 监控指标 -> 错误处理 -> 过载保护 -> 超时 -> 请求头 -> [PhaseBeforeAuth] -> 鉴权 -> [PhaseAfterAuth] ->
+// 参数校验 -> 日志 (敏感字段脱敏) -> SQL Action -> [PhaseBeforeHandler] -> 业务处理
+func ServerMiddlewares(
+	kind string,
+	customMiddlewares *injection.MiddlewareCollector,
+	sheddingMiddleware middleware.Middleware,
+	timeoutMiddleware middleware.Middleware,
+	logMiddleware middleware.Middleware,
+	actionMiddleware middleware.Middleware,
+) []middleware.Middleware {
+	middlewareSlice := []middleware.Middleware{
 		recovery.Recovery(recovery.WithHandler(middlewareutil.RecoveryHandler())),
 		metadata.Server(),
 		tracing.Server(),
+		metrics.Server(),
 		errorutil.ErrorResultMiddleware(),
-		middlewareutil.Validator(),
+		sheddingMiddleware,
+		timeoutMiddleware,
 		middlewarepkg.RequestAndResponseHeader(),
-		AuthorizationMiddleware(),
 	}
+
+	middlewareSlice = append(middlewareSlice, customMiddlewares.Phase(kind, injection.PhaseBeforeAuth)...)
+	middlewareSlice = append(middlewareSlice, AuthorizationMiddleware())
+	middlewareSlice = append(middlewareSlice, customMiddlewares.Phase(kind, injection.PhaseAfterAuth)...)
+
+	// 日志输出, SQL Action 处理器，确保在真正的业务逻辑执行之前触发, 日志中只记录脱敏后的请求
+	middlewareSlice = append(middlewareSlice,
+		middlewareutil.Validator(),
+		middlewareutil.MaskLog(logMiddleware),
+		actionMiddleware,
+	)
+	middlewareSlice = append(middlewareSlice, customMiddlewares.Phase(kind, injection.PhaseBeforeHandler)...)
+
+	return middlewareSlice
 }
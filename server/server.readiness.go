@@ -0,0 +1,75 @@
+package servers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// 默认的 HTTP 就绪检查路径
+const defaultReadinessPath = "/ready"
+
+// Readiness 为服务的就绪状态, 创建时即为就绪，服务退出时会在停止接收请求前置为不可用, 使负载均衡能够提前摘除流量.
+// HTTP 服务通过 /basic/config 中 shutdown 配置的 readiness_path (默认 /ready) 暴露就绪状态,
+// GRPC 服务通过标准的 grpc.health.v1.Health 接口暴露就绪状态
+type Readiness struct {
+	ready  atomic.Bool
+	health *health.Server
+}
+
+// NewReadiness 创建服务的就绪状态
+func NewReadiness() *Readiness {
+	r := &Readiness{
+		health: health.NewServer(),
+	}
+	r.ready.Store(true)
+
+	return r
+}
+
+// Ready 返回服务当前是否就绪
+func (r *Readiness) Ready() bool {
+	return r.ready.Load()
+}
+
+// SetReady 设置服务的就绪状态
+func (r *Readiness) SetReady(ready bool) {
+	r.ready.Store(ready)
+
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if ready {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	r.health.SetServingStatus("", status)
+}
+
+// ServeHTTP 实现 http.Handler, 就绪时返回 200, 否则返回 503
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if !r.Ready() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("not ready"))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// HealthServer 返回 GRPC 的健康检查服务
+func (r *Readiness) HealthServer() grpc_health_v1.HealthServer {
+	return r.health
+}
+
+// readinessPath 返回 HTTP 就绪检查的路径
+func readinessPath(conf *def.Configuration) string {
+	path := conf.Server.GetShutdown().GetReadinessPath()
+	if path == "" {
+		return defaultReadinessPath
+	}
+
+	return path
+}
@@ -1,6 +1,8 @@
 package inject
 
 import (
+	"fmt"
+
 	"github.com/eden-quan/go-biz-kit/injection"
 	"github.com/eden-quan/go-biz-kit/setup"
 )
@@ -9,13 +11,18 @@ import (
 Inject 提供了默认的 setup 依赖注入，主要包括了各个中间件的注入，需要使用以下的中间件，需要先注入 config 模块，通过 config 模块
 为中间件提供配置中心的配置信息，通过该函数可以得到以下注入信息
 
- 1. Logger 注入，提供了基础的日志功能，可通过 log.Logger 得到
+ 1. Logger 注入，提供了基础的日志功能，可通过 log.Logger 得到, 并根据 /middleware/log/config 中的 payload 配置记录请求、响应及消息内容
  2. LoggerManager 注入，提供了日志库管理能力，可通过 *LogManager 得到
  3. Redis 注入，提供缓存的访问及管理能力，可通过 kit.Redis 得到
  4. MongoDB 注入，提供了 MongoDB 数据库的访问能力，可通过 kit.MongoDB 得到
  5. MySQL 注入，提供了 MySQL 数据库的访问能力，可通过 kit.MySQL 得到
  6. Messaging 注入，提供了基于 RabbitMQ 的消息队列能力, 可通过 kt.MessageQueue 得到
  7. Tracing 注入，提供了全局的链路跟踪能力，所有通过依赖注入的客户端都能够自动得到链路跟踪的能力
+ 8. 多实例注入，提供了按名称获取数据库/Redis/MongoDB 实例的能力，可通过 kit.Databases / kit.Redises / kit.Mongos 得到,
+    如需通过 name 标签获取实例，请使用 InjectNamedDatabase / InjectNamedRedis / InjectNamedMongo
+ 9. 可选依赖注入，根据配置未启用的组件会返回空实现，其操作均返回 errorutil.ComponentDisabled 错误,
+    需要检查组件是否启用时可通过 injection.Optional[kit.Redis] 等可选依赖得到
+ 10. 服务间请求签名，通过依赖注入的客户端使用 /basic/secret 中 service_encrypt 的私钥为请求签名
 */
 func Inject() {
 	InjectIns(injection.GlobalInjector())
@@ -25,40 +32,68 @@ func Inject() {
 InjectIns 使用创建实例的方式提供了默认的 setup 依赖注入，主要包括了各个中间件的注入，需要使用以下的中间件，需要先注入 config 模块，通过 config 模块
 为中间件提供配置中心的配置信息，通过该函数可以得到以下注入信息
 
- 1. Logger 注入，提供了基础的日志功能，可通过 log.Logger 得到
+ 1. Logger 注入，提供了基础的日志功能，可通过 log.Logger 得到, 并根据 /middleware/log/config 中的 payload 配置记录请求、响应及消息内容
  2. LoggerManager 注入，提供了日志库管理能力，可通过 *LogManager 得到
  3. Redis 注入，提供缓存的访问及管理能力，可通过 kit.Redis 得到
  4. MongoDB 注入，提供了 MongoDB 数据库的访问能力，可通过 kit.MongoDB 得到
  5. MySQL 注入，提供了 MySQL 数据库的访问能力，可通过 kit.MySQL 得到
  6. Messaging 注入，提供了基于 RabbitMQ 的消息队列能力, 可通过 kt.MessageQueue 得到
  7. Tracing 注入，提供了全局的链路跟踪能力，所有通过依赖注入的客户端都能够自动得到链路跟踪的能力
+ 8. 多实例注入，提供了按名称获取数据库/Redis/MongoDB 实例的能力，可通过 kit.Databases / kit.Redises / kit.Mongos 得到,
+    如需通过 name 标签获取实例，请使用 InjectNamedDatabase / InjectNamedRedis / InjectNamedMongo
+ 9. 可选依赖注入，根据配置未启用的组件会返回空实现，其操作均返回 errorutil.ComponentDisabled 错误,
+    需要检查组件是否启用时可通过 injection.Optional[kit.Redis] 等可选依赖得到
+ 10. 服务间请求签名，通过依赖注入的客户端使用 /basic/secret 中 service_encrypt 的私钥为请求签名
 */
 func InjectIns(inj *injection.Injector) {
-	// inj.InjectHTTPMiddleware
-	inj.InjectMany(
-		setup.NewLogger,
-		setup.NewLoggerManager,
-		setup.NewRedis,
-		setup.NewMongoDB,
-		setup.NewMySQLDatabase,
-		setup.NewSQLDatabase,
-		setup.NewMessageQueue,
-		setup.NewTracing,
-		setup.NewHTTPClientFactory,
-		setup.NewGRPCClientFactory,
-	)
+	injectLogger(inj)
+	injectRedis(inj)
+	injectMongo(inj)
+	injectDatabase(inj)
+	injectMessageQueue(inj)
+	injectTracing(inj)
+	injectClient(inj)
+	injectSQLAction(inj)
+	injectProfile(inj)
+}
+
+// InjectNamedDatabase 将 kit.Databases 中名称为 names 的实例以 name 标签注入, 如 InjectNamedDatabase("orders") 后，
+// 可以通过 InjectWithParam(ctor, []string{`name:"orders"`}, nil) 获取对应的 kit.Database
+func InjectNamedDatabase(names ...string) {
+	InjectNamedDatabaseIns(injection.GlobalInjector(), names...)
+}
+
+// InjectNamedDatabaseIns 使用创建实例的方式注入命名数据库实例，具体见 InjectNamedDatabase
+func InjectNamedDatabaseIns(inj *injection.Injector, names ...string) {
+	for _, name := range names {
+		inj.InjectWithParam(setup.DatabaseByName(name), nil, []string{nameTag(name)})
+	}
+}
 
-	inj.InjectWithParam(setup.NewSQLActionManager, []string{"", `group:"sql_action_register"`}, nil)
+// InjectNamedRedis 将 kit.Redises 中名称为 names 的实例以 name 标签注入, 使用方式同 InjectNamedDatabase
+func InjectNamedRedis(names ...string) {
+	InjectNamedRedisIns(injection.GlobalInjector(), names...)
+}
+
+// InjectNamedRedisIns 使用创建实例的方式注入命名 Redis 实例，具体见 InjectNamedRedis
+func InjectNamedRedisIns(inj *injection.Injector, names ...string) {
+	for _, name := range names {
+		inj.InjectWithParam(setup.RedisByName(name), nil, []string{nameTag(name)})
+	}
+}
 
-	inj.Invoke(
-		injection.WithInvoke(
-			setup.NewTracing,
-		),
-	)
+// InjectNamedMongo 将 kit.Mongos 中名称为 names 的实例以 name 标签注入, 使用方式同 InjectNamedDatabase
+func InjectNamedMongo(names ...string) {
+	InjectNamedMongoIns(injection.GlobalInjector(), names...)
+}
+
+// InjectNamedMongoIns 使用创建实例的方式注入命名 MongoDB 实例，具体见 InjectNamedMongo
+func InjectNamedMongoIns(inj *injection.Injector, names ...string) {
+	for _, name := range names {
+		inj.InjectWithParam(setup.MongoByName(name), nil, []string{nameTag(name)})
+	}
+}
 
-	inj.Invoke(
-		injection.WithInvoke(
-			setup.NewProfile,
-		),
-	)
+func nameTag(name string) string {
+	return fmt.Sprintf(`name:"%s"`, name)
 }
@@ -0,0 +1,157 @@
+package inject
+
+import (
+	"github.com/eden-quan/go-biz-kit/injection"
+	"github.com/eden-quan/go-biz-kit/setup"
+)
+
+// Modules 返回 setup 提供的所有模块，与 InjectIns 注入的依赖相同, 可通过 injection.Install 进行安装
+func Modules() []*injection.Module {
+	return []*injection.Module{
+		LoggerModule(),
+		RedisModule(),
+		MongoModule(),
+		DatabaseModule(),
+		MessageQueueModule(),
+		TracingModule(),
+		ClientModule(),
+		SQLActionModule(),
+		ProfileModule(),
+	}
+}
+
+// LoggerModule 日志模块 logger，提供 log.Logger / *setup.LoggerManager 及服务退出时用于清理资源的 *setup.Closers, 并将 fx 的内部事件输出到日志中
+func LoggerModule() *injection.Module {
+	return injection.NewModule("logger").Require("config").With(injectLogger)
+}
+
+// RedisModule Redis 模块 redis，提供 kit.Redis / kit.Redises / injection.Optional[kit.Redis]
+func RedisModule() *injection.Module {
+	return injection.NewModule("redis").Require("config", "logger").With(injectRedis)
+}
+
+// MongoModule MongoDB 模块 mongo，提供 kit.MongoDB / kit.Mongos / injection.Optional[kit.MongoDB]
+func MongoModule() *injection.Module {
+	return injection.NewModule("mongo").Require("config", "logger").With(injectMongo)
+}
+
+// DatabaseModule 数据库模块 database，提供 kit.MySQL / kit.Database / kit.Databases 及其可选依赖
+func DatabaseModule() *injection.Module {
+	return injection.NewModule("database").Require("config", "logger").With(injectDatabase)
+}
+
+// MessageQueueModule 消息队列模块 messaging，提供 kit.MessageQueue / injection.Optional[kit.MessageQueue]
+func MessageQueueModule() *injection.Module {
+	return injection.NewModule("messaging").Require("config", "logger").With(injectMessageQueue)
+}
+
+// TracingModule 链路跟踪模块 tracing，在启动时初始化全局的链路跟踪
+func TracingModule() *injection.Module {
+	return injection.NewModule("tracing").Require("config", "logger").With(injectTracing)
+}
+
+// ClientModule 客户端模块 client，提供 HTTP 及 GRPC 客户端的创建工厂
+func ClientModule() *injection.Module {
+	return injection.NewModule("client").Require("logger").With(injectClient)
+}
+
+// SQLActionModule SQL Action 模块 sqlaction，提供 *setup.ActionManager
+func SQLActionModule() *injection.Module {
+	return injection.NewModule("sqlaction").Require("logger").With(injectSQLAction)
+}
+
+// ProfileModule 性能分析模块 profile，根据配置在启动时开启 CPU 及内存分析
+func ProfileModule() *injection.Module {
+	return injection.NewModule("profile").Require("config", "logger").With(injectProfile)
+}
+
+func injectLogger(inj *injection.Injector) {
+	inj.InjectMany(
+		setup.NewLogger,
+		setup.NewLoggerManager,
+		setup.NewClosers,
+	)
+
+	// 将 fx 的内部事件输出到日志中
+	inj.Invoke(
+		injection.WithReplace(
+			setup.BindFxLogger,
+		),
+	)
+
+	// 请求、响应及消息内容的日志, 未启用时不记录
+	inj.Invoke(
+		injection.WithInvoke(
+			setup.NewPayloadLog,
+		),
+	)
+}
+
+func injectRedis(inj *injection.Injector) {
+	inj.InjectMany(
+		setup.NewRedis,
+		setup.NewRedises,
+		setup.NewOptionalRedis,
+	)
+}
+
+func injectMongo(inj *injection.Injector) {
+	inj.InjectMany(
+		setup.NewMongoDB,
+		setup.NewMongos,
+		setup.NewOptionalMongoDB,
+	)
+}
+
+func injectDatabase(inj *injection.Injector) {
+	inj.InjectMany(
+		setup.NewMySQLDatabase,
+		setup.NewSQLDatabase,
+		setup.NewDatabases,
+		setup.NewOptionalDatabase,
+		setup.NewOptionalMySQL,
+	)
+}
+
+func injectMessageQueue(inj *injection.Injector) {
+	inj.InjectMany(
+		setup.NewMessageQueue,
+		setup.NewOptionalMessageQueue,
+	)
+}
+
+func injectTracing(inj *injection.Injector) {
+	inj.Inject(setup.NewTracing)
+
+	inj.Invoke(
+		injection.WithInvoke(
+			setup.NewTracing,
+		),
+	)
+}
+
+func injectClient(inj *injection.Injector) {
+	inj.InjectMany(
+		setup.NewHTTPClientFactory,
+		setup.NewGRPCClientFactory,
+	)
+
+	// 调用其他服务时使用 /basic/secret 中的私钥签名
+	inj.Invoke(
+		injection.WithInvoke(
+			setup.NewSignature,
+		),
+	)
+}
+
+func injectSQLAction(inj *injection.Injector) {
+	inj.InjectWithParam(setup.NewSQLActionManager, []string{"", `group:"sql_action_register"`}, nil)
+}
+
+func injectProfile(inj *injection.Injector) {
+	inj.Invoke(
+		injection.WithInvoke(
+			setup.NewProfile,
+		),
+	)
+}
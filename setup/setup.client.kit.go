@@ -5,28 +5,29 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	etcd "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 
 	"github.com/eden-quan/go-biz-kit/client"
 	"github.com/eden-quan/go-biz-kit/config/def"
 )
 
-func NewGRPCClientFactory(logger log.Logger) clientutil.RegisterGRPCClientFactoryType {
+func NewGRPCClientFactory(logger log.Logger, etcdClient *etcd.Client) clientutil.RegisterGRPCClientFactoryType {
 	return func(conf *def.Server) (grpc.ClientConnInterface, error) {
 		if conf.Grpc == nil {
 			return nil, errors.New("wanna create grpc client but grpc config is empty")
 		}
 
-		return clientutil.NewGrpcClientConn(conf, logger)
+		return clientutil.NewGrpcClientConn(conf, etcdClient, logger)
 	}
 }
 
-func NewHTTPClientFactory(logger log.Logger) clientutil.RegisterHTTPClientFactoryType {
+func NewHTTPClientFactory(logger log.Logger, etcdClient *etcd.Client) clientutil.RegisterHTTPClientFactoryType {
 	return func(conf *def.Server) (*http.Client, error) {
 		if conf.Http == nil {
 			return nil, errors.New("wanna create http client but grpc config is empty")
 		}
 
-		return clientutil.NewHttpClientConn(conf, logger)
+		return clientutil.NewHttpClientConn(conf, etcdClient, logger)
 	}
 }
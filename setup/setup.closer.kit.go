@@ -1,6 +1,105 @@
 package setup
 
-// NewSetupCloseOption 用于注入到依赖中，用于程序退出时清理资源
-// TODO: 暂时无需使用
-func NewSetupCloseOption() {
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.uber.org/fx"
+)
+
+// CloseStage 为资源的关闭阶段, 服务退出时按照阶段从小到大依次关闭资源
+type CloseStage int
+
+const (
+	// CloseStageMessaging 消息队列, 最先关闭以停止消费新的消息
+	CloseStageMessaging CloseStage = iota
+	// CloseStageStorage 数据库 / Redis / MongoDB 等存储的连接池
+	CloseStageStorage
+	// CloseStageTracing 链路跟踪, 最后关闭以便上报关闭过程中产生的链路数据
+	CloseStageTracing
+)
+
+type closer struct {
+	name  string
+	stage CloseStage
+	close func(ctx context.Context) error
+}
+
+// Closers 记录了程序退出时需要清理的资源, 由各个组件在创建时进行注册,
+// 服务退出时会在等待处理中的请求完成后调用 Close 关闭资源, 未使用服务模块时则在 fx 的 OnStop 中关闭
+type Closers struct {
+	lock    sync.Mutex
+	logger  *log.Helper
+	closers []closer
+	closed  bool
+}
+
+// NewClosers 创建资源清理器
+func NewClosers(lifecycle fx.Lifecycle, logger log.Logger) *Closers {
+	c := &Closers{
+		logger:  log.NewHelper(log.With(logger, "module", "closer")),
+		closers: make([]closer, 0),
+	}
+
+	lifecycle.Append(fx.Hook{
+		OnStop: c.Close,
+	})
+
+	return c
+}
+
+// Add 注册一个在 stage 阶段关闭的资源, name 用于输出日志, 重复注册同名资源时只保留最后一次注册的关闭函数
+func (c *Closers) Add(name string, stage CloseStage, fn func(ctx context.Context) error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, cl := range c.closers {
+		if cl.name == name {
+			c.closers[i] = closer{name: name, stage: stage, close: fn}
+			return
+		}
+	}
+
+	c.closers = append(c.closers, closer{name: name, stage: stage, close: fn})
+}
+
+// Close 按照阶段依次关闭已注册的资源, 同一阶段内按注册的逆序关闭, 多次调用时只有第一次生效,
+// 关闭失败的资源会输出错误日志并继续关闭其他资源, 最终返回第一个错误
+func (c *Closers) Close(ctx context.Context) error {
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		return nil
+	}
+	c.closed = true
+
+	closers := make([]closer, len(c.closers))
+	for i, cl := range c.closers {
+		closers[len(closers)-1-i] = cl
+	}
+	c.lock.Unlock()
+
+	sort.SliceStable(closers, func(i, j int) bool {
+		return closers[i].stage < closers[j].stage
+	})
+
+	var first error
+	for _, cl := range closers {
+		start := time.Now()
+		err := cl.close(ctx)
+		if err != nil {
+			c.logger.Errorw("msg", "close resource failed", "resource", cl.name, "err", err)
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+
+		c.logger.Infow("msg", "resource closed", "resource", cl.name, "runtime", time.Since(start).String())
+	}
+
+	return first
 }
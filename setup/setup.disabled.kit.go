@@ -0,0 +1,129 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"net"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+
+	kit "github.com/eden-quan/go-biz-kit"
+	"github.com/eden-quan/go-biz-kit/database"
+	errorutil "github.com/eden-quan/go-biz-kit/error"
+)
+
+// 根据配置未启用的组件不再返回 nil，而是返回对应的空实现，空实现的所有操作都会返回 errorutil.ComponentDisabled 错误,
+// 使用者可以通过 kit.IsDisabled 或 injection.Optional 检查组件是否启用
+
+// disabledRedisHook 拦截所有 Redis 命令并返回组件未启用错误, 不会建立任何连接
+type disabledRedisHook struct{}
+
+func (h disabledRedisHook) DialHook(_ redis.DialHook) redis.DialHook {
+	return func(_ context.Context, _, _ string) (net.Conn, error) {
+		return nil, disabledError("redis")
+	}
+}
+
+func (h disabledRedisHook) ProcessHook(_ redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		err := disabledError("redis")
+		cmd.SetErr(err)
+		return err
+	}
+}
+
+func (h disabledRedisHook) ProcessPipelineHook(_ redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(_ context.Context, cmds []redis.Cmder) error {
+		err := disabledError("redis")
+		for _, cmd := range cmds {
+			cmd.SetErr(err)
+		}
+		return err
+	}
+}
+
+type disabledRedis struct {
+	redisImpl
+}
+
+func (r *disabledRedis) Disabled() bool {
+	return true
+}
+
+func newDisabledRedis() kit.Redis {
+	client := redis.NewClient(&redis.Options{Addr: "disabled:6379"})
+	client.AddHook(disabledRedisHook{})
+
+	return &disabledRedis{redisImpl{db: client}}
+}
+
+// disabledConnector 为未启用数据库时使用的连接器, 建立连接时返回组件未启用错误
+type disabledConnector struct{}
+
+func (c disabledConnector) Connect(_ context.Context) (sqldriver.Conn, error) {
+	return nil, disabledError("database")
+}
+
+func (c disabledConnector) Driver() sqldriver.Driver {
+	return c
+}
+
+func (c disabledConnector) Open(_ string) (sqldriver.Conn, error) {
+	return nil, disabledError("database")
+}
+
+type disabledDatabase struct {
+	*database.DBImpl
+}
+
+func (d *disabledDatabase) Disabled() bool {
+	return true
+}
+
+func newDisabledDatabase() kit.Database {
+	db := sqlx.NewDb(sql.OpenDB(disabledConnector{}), "disabled")
+	return &disabledDatabase{database.NewDB(db)}
+}
+
+// disabledDeployment 为未启用 MongoDB 时使用的部署, 选择服务器时返回组件未启用错误,
+// 使用自定义部署时客户端不会启动拓扑监控, 因此不会在后台持续建立连接
+type disabledDeployment struct{}
+
+func (d disabledDeployment) SelectServer(_ context.Context, _ description.ServerSelector) (driver.Server, error) {
+	return nil, disabledError("mongodb")
+}
+
+func (d disabledDeployment) Kind() description.TopologyKind {
+	return description.Single
+}
+
+type disabledMongoDB struct {
+	mongoDBImpl
+}
+
+func (db *disabledMongoDB) Disabled() bool {
+	return true
+}
+
+func newDisabledMongoDB(database string) (kit.MongoDB, error) {
+	opt := options.Client()
+	// Deployment 为驱动内部使用的选项, 此处用于避免未启用的组件建立连接
+	opt.Deployment = disabledDeployment{}
+
+	client, err := mongo.Connect(context.Background(), opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &disabledMongoDB{mongoDBImpl{db: client.Database(database)}}, nil
+}
+
+func disabledError(component string) error {
+	return errorutil.ComponentDisabled.ToError("%s is disabled, please check the config center", component)
+}
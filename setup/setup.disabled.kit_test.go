@@ -0,0 +1,69 @@
+package setup
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	kit "github.com/eden-quan/go-biz-kit"
+	errorutil "github.com/eden-quan/go-biz-kit/error"
+	"github.com/eden-quan/go-biz-kit/message"
+)
+
+// go test -v -count=1 ./setup -test.run=TestDisabledComponents
+func TestDisabledComponents(t *testing.T) {
+	ctx := context.Background()
+	goroutines := runtime.NumGoroutine()
+
+	rds := newDisabledRedis()
+	require.True(t, kit.IsDisabled(rds))
+	require.True(t, errorutil.IsComponentDisabled(rds.Get().Set(ctx, "key", "value", 0).Err()))
+	require.True(t, errorutil.IsComponentDisabled(rds.Get().Get(ctx, "key").Err()))
+	_, err := rds.Get().Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, "key")
+		return nil
+	})
+	require.True(t, errorutil.IsComponentDisabled(err))
+
+	db := newDisabledDatabase()
+	require.True(t, kit.IsDisabled(db))
+	_, err = db.Get().ExecContext(ctx, "SELECT 1")
+	require.True(t, errorutil.IsComponentDisabled(err))
+	require.Error(t, db.WithTx(ctx, func(context.Context, kit.Transaction) error { return nil }))
+
+	mongo, err := newDisabledMongoDB("orders")
+	require.NoError(t, err)
+	require.True(t, kit.IsDisabled(mongo))
+	_, err = mongo.Get().Collection("order").InsertOne(ctx, bson.M{"id": 1})
+	require.True(t, errorutil.IsComponentDisabled(err))
+	_, err = mongo.Get().Collection("order").Find(ctx, bson.M{})
+	require.True(t, errorutil.IsComponentDisabled(err))
+
+	mq := &messageQueueImpl{factory: message.NewDisabledQueueFactory()}
+	require.True(t, kit.IsDisabled(mq))
+	_, err = mq.Get().Producer(&message.TopicConfig{})
+	require.True(t, errorutil.IsComponentDisabled(err))
+	_, err = mq.Get().Consumer(&message.TopicConfig{})
+	require.True(t, errorutil.IsComponentDisabled(err))
+
+	// 未启用的组件不会在后台建立连接
+	time.Sleep(time.Millisecond * 100)
+	require.LessOrEqual(t, runtime.NumGoroutine(), goroutines+1)
+
+	// 未启用的组件对应的 Optional 不存在
+	require.False(t, NewOptionalRedis(rds).Present())
+	require.False(t, NewOptionalDatabase(db).Present())
+	require.False(t, NewOptionalMongoDB(mongo).Present())
+	require.False(t, NewOptionalMessageQueue(mq).Present())
+	require.Nil(t, NewOptionalRedis(rds).OrElse(nil))
+
+	enabled := &redisImpl{}
+	value, ok := NewOptionalRedis(enabled).Get()
+	require.True(t, ok)
+	require.Same(t, enabled, value)
+}
@@ -0,0 +1,34 @@
+package setup
+
+import (
+	"sort"
+)
+
+// instanceSet 为按名称管理的多实例集合, 用于实现 kit.Databases / kit.Redises / kit.Mongos
+type instanceSet[T any] struct {
+	instances map[string]T
+}
+
+func newInstanceSet[T any]() *instanceSet[T] {
+	return &instanceSet[T]{
+		instances: make(map[string]T),
+	}
+}
+
+func (s *instanceSet[T]) add(name string, ins T) {
+	s.instances[name] = ins
+}
+
+func (s *instanceSet[T]) Get(name string) T {
+	return s.instances[name]
+}
+
+func (s *instanceSet[T]) Names() []string {
+	names := make([]string, 0, len(s.instances))
+	for name := range s.instances {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -17,6 +17,8 @@ import (
 
 	config2 "github.com/eden-quan/go-biz-kit/config"
 	config "github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/injection"
+	"github.com/eden-quan/go-biz-kit/payload"
 )
 
 // LoggerPrefixField with logger fields.
@@ -65,6 +67,22 @@ func NewLogger(manager *LoggerManager) (log.Logger, error) {
 	return manager.Logger()
 }
 
+// BindFxLogger 在日志管理器创建后将依赖注入 (fx) 的内部事件输出到 Logger 中, 事件的输出级别通过 /middleware/log/config 中的 fx_level 控制,
+// 该函数作为 *LoggerManager 的装饰器使用，只有在日志被使用时才会触发
+func BindFxLogger(manager *LoggerManager, fxLogger *injection.FxLogger) *LoggerManager {
+	logger, err := manager.Logger()
+	if err == nil && logger != nil {
+		fxLogger.SetLogger(logger, manager.conf.Log.GetFxLevel)
+	}
+
+	return manager
+}
+
+// NewPayloadLog 根据 /middleware/log/config 中的 payload 配置记录服务端、客户端及消息队列的请求及响应内容, 配置在每次记录时读取
+func NewPayloadLog(configuration *config.Configuration) {
+	payload.Setup(configuration.Log.GetPayload)
+}
+
 // NewLoggerManager 提供一个日志管理器，可以用他创建日志实例以及进行一些初始化操作
 func NewLoggerManager(local *config2.LocalConfigure, configuration *config.Configuration) *LoggerManager {
 	return &LoggerManager{
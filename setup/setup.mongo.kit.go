@@ -1,6 +1,8 @@
 package setup
 
 import (
+	"fmt"
+
 	"github.com/go-kratos/kratos/v2/log"
 	"go.mongodb.org/mongo-driver/mongo"
 
@@ -24,15 +26,49 @@ func (db *mongoDBImpl) Get() *mongo.Database {
 	return db.db
 }
 
-// NewMongoDB 创建 MongoDB 客户端 mongo database
-func NewMongoDB(conf *config.Configuration, logger log.Logger) (kit.MongoDB, error) {
+// NewMongoDB 创建 MongoDB 客户端 mongo database, 未启用时返回的客户端所有操作都会返回 errorutil.ComponentDisabled 错误
+func NewMongoDB(conf *config.Configuration, logger log.Logger, closers *Closers) (kit.MongoDB, error) {
 
 	if !conf.Mongo.GetEnable() {
-		return nil, nil
+		return newDisabledMongoDB(conf.Mongo.GetDatabase())
 	}
 
-	mongoConfig := &conf.Mongo
+	db := newMongoDBWithConfig(&conf.Mongo, logger)
+	closers.Add("mongodb", CloseStageStorage, db.Get().Client().Disconnect)
+
+	return db, nil
+}
+
+// NewMongos 根据 /middleware/mongodb/instances 中的配置创建多实例 MongoDB, 未启用的实例会被忽略
+func NewMongos(conf *config.Configuration, logger log.Logger, closers *Closers) (kit.Mongos, error) {
+	set := newInstanceSet[kit.MongoDB]()
+
+	for name, mongoConfig := range conf.MongoInstances.GetInstances() {
+		if !mongoConfig.GetEnable() {
+			continue
+		}
+
+		db := newMongoDBWithConfig(mongoConfig, log.With(logger, "instance", name))
+		closers.Add("mongodb/"+name, CloseStageStorage, db.Get().Client().Disconnect)
+		set.add(name, db)
+	}
+
+	return set, nil
+}
+
+// MongoByName 返回从 kit.Mongos 中获取名称为 name 的实例的构造函数, 一般配合 name 标签进行注入
+func MongoByName(name string) func(mgs kit.Mongos) (kit.MongoDB, error) {
+	return func(mgs kit.Mongos) (kit.MongoDB, error) {
+		db := mgs.Get(name)
+		if db == nil {
+			return nil, fmt.Errorf("mongodb instance %s is not configured or disabled, please check /middleware/mongodb/instances", name)
+		}
+
+		return db, nil
+	}
+}
 
+func newMongoDBWithConfig(mongoConfig *config.Mongo, logger log.Logger) kit.MongoDB {
 	c := &mongopkg.Config{
 		Addr:              mongoConfig.GetAddress(),
 		MaxPoolSize:       mongoConfig.GetMaxPoolSize(),
@@ -47,6 +83,6 @@ func NewMongoDB(conf *config.Configuration, logger log.Logger) (kit.MongoDB, err
 	}
 	client := mongopkg.NewMongoClient(c, logger)
 
-	db := client.Database(mongoConfig.Database)
-	return newMongoDB(db), nil
+	db := client.Database(mongoConfig.GetDatabase())
+	return newMongoDB(db)
 }
@@ -1,6 +1,9 @@
 package setup
 
 import (
+	"context"
+	"io"
+
 	"github.com/go-kratos/kratos/v2/log"
 
 	kit "github.com/eden-quan/go-biz-kit"
@@ -17,7 +20,18 @@ func (m *messageQueueImpl) Get() message.QueueFactory {
 	return m.factory
 }
 
-func NewMessageQueue(logger log.Logger, conf *def.Configuration, local *config.LocalConfigure) (kit.MessageQueue, error) {
+func (m *messageQueueImpl) Disabled() bool {
+	return kit.IsDisabled(m.factory)
+}
+
+// NewMessageQueue 创建消息队列, 服务退出时会关闭消息队列的连接以停止消费
+func NewMessageQueue(logger log.Logger, conf *def.Configuration, local *config.LocalConfigure, closers *Closers) (kit.MessageQueue, error) {
 	factory, err := message.NewQueueFactory(logger, conf, local)
+	if c, ok := factory.(io.Closer); ok && err == nil {
+		closers.Add("rabbitmq", CloseStageMessaging, func(_ context.Context) error {
+			return c.Close()
+		})
+	}
+
 	return &messageQueueImpl{factory: factory}, err
 }
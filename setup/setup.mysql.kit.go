@@ -1,6 +1,9 @@
 package setup
 
 import (
+	"context"
+	"fmt"
+
 	_ "github.com/glebarez/go-sqlite"
 	"github.com/go-kratos/kratos/v2/log"
 	_ "github.com/go-sql-driver/mysql"
@@ -14,24 +17,72 @@ import (
 )
 
 // NewMySQLDatabase 创建 MySQL 客户端
-func NewMySQLDatabase(conf *def.Configuration, logger log.Logger) (kit.MySQL, error) {
-	return NewSQLDatabase(conf, logger)
+func NewMySQLDatabase(conf *def.Configuration, logger log.Logger, closers *Closers) (kit.MySQL, error) {
+	return NewSQLDatabase(conf, logger, closers)
 }
 
-// NewSQLDatabase 创建满足 SQL 规范的客户端
-func NewSQLDatabase(conf *def.Configuration, logger log.Logger) (kit.Database, error) {
+// NewSQLDatabase 创建满足 SQL 规范的客户端, 未启用时返回的客户端所有操作都会返回 errorutil.ComponentDisabled 错误
+func NewSQLDatabase(conf *def.Configuration, logger log.Logger, closers *Closers) (kit.Database, error) {
 	config := &conf.Database
 
 	if !config.GetEnable() {
-		return nil, nil
+		return newDisabledDatabase(), nil
+	}
+
+	db, err := newSQLDatabase(config, conf.Tracing.GetEnable(), logger)
+	if err != nil {
+		return nil, err
+	}
+	closers.Add(config.GetDriver(), CloseStageStorage, closeSQLDatabase(db))
+
+	return db, nil
+}
+
+// NewDatabases 根据 /middleware/database/instances 中的配置创建多实例数据库, 未启用的实例会被忽略
+func NewDatabases(conf *def.Configuration, logger log.Logger, closers *Closers) (kit.Databases, error) {
+	set := newInstanceSet[kit.Database]()
+
+	for name, config := range conf.Databases.GetInstances() {
+		if !config.GetEnable() {
+			continue
+		}
+
+		db, err := newSQLDatabase(config, conf.Tracing.GetEnable(), log.With(logger, "instance", name))
+		if err != nil {
+			return nil, err
+		}
+		closers.Add(config.GetDriver()+"/"+name, CloseStageStorage, closeSQLDatabase(db))
+		set.add(name, db)
 	}
 
+	return set, nil
+}
+
+func closeSQLDatabase(db kit.Database) func(ctx context.Context) error {
+	return func(_ context.Context) error {
+		return db.Get().Close()
+	}
+}
+
+// DatabaseByName 返回从 kit.Databases 中获取名称为 name 的实例的构造函数, 一般配合 name 标签进行注入
+func DatabaseByName(name string) func(dbs kit.Databases) (kit.Database, error) {
+	return func(dbs kit.Databases) (kit.Database, error) {
+		db := dbs.Get(name)
+		if db == nil {
+			return nil, fmt.Errorf("database instance %s is not configured or disabled, please check /middleware/database/instances", name)
+		}
+
+		return db, nil
+	}
+}
+
+func newSQLDatabase(config *def.Database, tracing bool, logger log.Logger) (kit.Database, error) {
 	var err error = nil
 	var db *sqlx.DB
 
 	driver := config.GetDriver()
 	// [username[:password]@][protocol[(address)]]/dbname[?param1=value1&...&paramN=valueN]
-	if conf.Tracing.GetEnable() {
+	if tracing {
 		db, err = splunksqlx.Open(driver, config.GetAddr())
 	} else {
 		db, err = sqlx.Open(driver, config.GetAddr())
@@ -0,0 +1,40 @@
+package setup
+
+import (
+	kit "github.com/eden-quan/go-biz-kit"
+	"github.com/eden-quan/go-biz-kit/injection"
+)
+
+// optionalOf 根据组件是否启用创建 injection.Optional
+func optionalOf[T any](component T) injection.Optional[T] {
+	if kit.IsDisabled(component) {
+		return injection.None[T]()
+	}
+
+	return injection.Some(component)
+}
+
+// NewOptionalRedis 提供 injection.Optional[kit.Redis], 未启用 Redis 时依赖不存在
+func NewOptionalRedis(r kit.Redis) injection.Optional[kit.Redis] {
+	return optionalOf(r)
+}
+
+// NewOptionalMongoDB 提供 injection.Optional[kit.MongoDB], 未启用 MongoDB 时依赖不存在
+func NewOptionalMongoDB(db kit.MongoDB) injection.Optional[kit.MongoDB] {
+	return optionalOf(db)
+}
+
+// NewOptionalDatabase 提供 injection.Optional[kit.Database], 未启用数据库时依赖不存在
+func NewOptionalDatabase(db kit.Database) injection.Optional[kit.Database] {
+	return optionalOf(db)
+}
+
+// NewOptionalMySQL 提供 injection.Optional[kit.MySQL], 未启用数据库时依赖不存在
+func NewOptionalMySQL(db kit.MySQL) injection.Optional[kit.MySQL] {
+	return optionalOf(db)
+}
+
+// NewOptionalMessageQueue 提供 injection.Optional[kit.MessageQueue], 未启用消息队列时依赖不存在
+func NewOptionalMessageQueue(mq kit.MessageQueue) injection.Optional[kit.MessageQueue] {
+	return optionalOf(mq)
+}
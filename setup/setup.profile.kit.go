@@ -12,6 +12,8 @@ import (
 	"github.com/eden-quan/go-biz-kit/config/def"
 )
 
+// NewProfile 根据 /basic/profile/config 在启动时开始采集 CPU 性能数据, 并在退出时写入文件及内存快照,
+// 需要在运行期间按需采集时可以启用 /basic/config 中的 admin 管理端点, 见 servers.StartAdminServer
 func NewProfile(lifecycle fx.Lifecycle, conf *def.Configuration, logger log.Logger) {
 
 	var helper = log.NewHelper(logger)
@@ -2,6 +2,7 @@ package setup
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/redis/go-redis/v9"
@@ -24,18 +25,54 @@ func (r *redisImpl) Get() redis.UniversalClient {
 	return r.db
 }
 
-// NewRedis 创建 redis 客户端
-func NewRedis(conf *def.Configuration, logger log.Logger) (kit.Redis, error) {
+// NewRedis 创建 redis 客户端, 未启用时返回的客户端所有操作都会返回 errorutil.ComponentDisabled 错误
+func NewRedis(conf *def.Configuration, logger log.Logger, closers *Closers) (kit.Redis, error) {
 	redisConfig := &conf.Redis
 	if !redisConfig.GetEnable() {
-		return nil, nil
+		return newDisabledRedis(), nil
 	}
 
 	db := NewRedisClient(redisConfig, logger)
+	closers.Add("redis", CloseStageStorage, closeRedis(db))
 
 	return newRedis(db), nil
 }
 
+// NewRedises 根据 /middleware/redis/instances 中的配置创建多实例 Redis, 未启用的实例会被忽略
+func NewRedises(conf *def.Configuration, logger log.Logger, closers *Closers) (kit.Redises, error) {
+	set := newInstanceSet[kit.Redis]()
+
+	for name, config := range conf.RedisInstances.GetInstances() {
+		if !config.GetEnable() {
+			continue
+		}
+
+		db := NewRedisClient(config, log.With(logger, "instance", name))
+		closers.Add("redis/"+name, CloseStageStorage, closeRedis(db))
+		set.add(name, newRedis(db))
+	}
+
+	return set, nil
+}
+
+func closeRedis(db redis.UniversalClient) func(ctx context.Context) error {
+	return func(_ context.Context) error {
+		return db.Close()
+	}
+}
+
+// RedisByName 返回从 kit.Redises 中获取名称为 name 的实例的构造函数, 一般配合 name 标签进行注入
+func RedisByName(name string) func(rds kit.Redises) (kit.Redis, error) {
+	return func(rds kit.Redises) (kit.Redis, error) {
+		rd := rds.Get(name)
+		if rd == nil {
+			return nil, fmt.Errorf("redis instance %s is not configured or disabled, please check /middleware/redis/instances", name)
+		}
+
+		return rd, nil
+	}
+}
+
 func NewRedisClient(config *def.Redis, logger log.Logger) redis.UniversalClient {
 	lh := log.NewHelper(log.With(logger, "module", "redis"))
 
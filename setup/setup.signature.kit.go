@@ -0,0 +1,15 @@
+package setup
+
+import (
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+	"github.com/eden-quan/go-biz-kit/signature"
+)
+
+// NewSignature 使用 /basic/secret 中 service_encrypt 的私钥为调用其他服务的请求签名, 服务名为当前应用的名称,
+// 私钥在每次调用时读取, 未配置私钥时请求不签名
+func NewSignature(conf *def.Configuration, local *config.LocalConfigure) {
+	signature.Setup(local.APP.Name, func() string {
+		return conf.Secret.GetServiceEncrypt().GetPrivateKey()
+	})
+}
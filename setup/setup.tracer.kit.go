@@ -8,7 +8,12 @@ import (
 	"github.com/eden-quan/go-biz-kit/tracing"
 )
 
-// NewTracing 创建链路跟踪
-func NewTracing(conf *def.Configuration, logger log.Logger, local *config.LocalConfigure) (*tracing.TracerInitializer, error) {
-	return tracing.InitTracing(conf, logger, local)
+// NewTracing 创建链路跟踪, 服务退出时会上报缓存中的链路数据并关闭链路跟踪器
+func NewTracing(conf *def.Configuration, logger log.Logger, local *config.LocalConfigure, closers *Closers) (*tracing.TracerInitializer, error) {
+	initializer, err := tracing.InitTracing(conf, logger, local)
+	if err == nil {
+		closers.Add("tracing", CloseStageTracing, tracing.Shutdown)
+	}
+
+	return initializer, err
 }
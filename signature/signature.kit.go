@@ -0,0 +1,227 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	stdjson "encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+)
+
+// 签名相关的请求头, 服务名与链路跟踪中使用的请求头一致
+const (
+	ServiceHeader   = "x-md-service-name"
+	TimestampHeader = "x-md-signature-timestamp"
+	NonceHeader     = "x-md-signature-nonce"
+	SignatureHeader = "x-md-signature"
+)
+
+// signer 为当前服务的签名配置, 由 Setup 设置
+var signer atomic.Pointer[clientSigner]
+
+type clientSigner struct {
+	service    string
+	privateKey func() string
+
+	lock sync.Mutex
+	pem  string
+	key  crypto.Signer
+}
+
+// Setup 设置调用其他服务时使用的服务名及私钥, privateKey 返回当前 PEM 格式的私钥, 私钥变化后自动使用新的私钥,
+// 支持 RSA、ECDSA 及 Ed25519, 未配置私钥时请求不签名
+func Setup(service string, privateKey func() string) {
+	signer.Store(&clientSigner{service: service, privateKey: privateKey})
+}
+
+// Client 为发出的请求签名, 签名内容包括 Operation、时间戳、随机数、调用方服务名及请求体的摘要,
+// 重试时每次调用重新签名, 未通过 Setup 配置私钥时不做任何处理, 私钥无法解析时返回错误, 不会发出未签名的请求
+func Client() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			s := signer.Load()
+			tr, ok := transport.FromClientContext(ctx)
+			if s == nil || !ok {
+				return handler(ctx, req)
+			}
+
+			key, err := s.signingKey()
+			if err != nil {
+				// 私钥配置错误时不发出未签名的请求, 避免只能从被调用方的拒绝中发现问题
+				return nil, fmt.Errorf("parse signature private key of service %s failed: %w", s.service, err)
+			}
+			if key == nil {
+				return handler(ctx, req)
+			}
+
+			digest, err := bodyDigest(req)
+			if err != nil {
+				return nil, err
+			}
+
+			nonce := make([]byte, 16)
+			if _, err := rand.Read(nonce); err != nil {
+				return nil, err
+			}
+
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			header := &request{
+				operation: tr.Operation(),
+				timestamp: timestamp,
+				nonce:     hex.EncodeToString(nonce),
+				service:   s.service,
+				digest:    digest,
+			}
+
+			sig, err := sign(key, header.payload())
+			if err != nil {
+				return nil, err
+			}
+
+			tr.RequestHeader().Set(ServiceHeader, header.service)
+			tr.RequestHeader().Set(TimestampHeader, header.timestamp)
+			tr.RequestHeader().Set(NonceHeader, header.nonce)
+			tr.RequestHeader().Set(SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+
+			return handler(ctx, req)
+		}
+	}
+}
+
+// signingKey 返回当前的私钥, 私钥变化时重新解析
+func (s *clientSigner) signingKey() (crypto.Signer, error) {
+	content := s.privateKey()
+	if content == "" {
+		return nil, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if content != s.pem {
+		key, err := parsePrivateKey(content)
+		if err != nil {
+			return nil, err
+		}
+		s.pem, s.key = content, key
+	}
+
+	return s.key, nil
+}
+
+// request 为参与签名的请求信息
+type request struct {
+	operation string
+	timestamp string
+	nonce     string
+	service   string
+	digest    string
+}
+
+func (r *request) payload() []byte {
+	return []byte(strings.Join([]string{r.operation, r.timestamp, r.nonce, r.service, r.digest}, "\n"))
+}
+
+// bodyDigest 返回请求体的 SHA256 摘要, proto 消息使用确定性的序列化, 保证 HTTP 及 GRPC 解码后得到相同的摘要,
+// 因此调用方与服务方需要使用相同的 proto 定义
+func bodyDigest(req interface{}) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch m := req.(type) {
+	case nil:
+	case proto.Message:
+		data, err = proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	default:
+		data, err = stdjson.Marshal(m)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func sign(key crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(payload)
+	return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func verify(key crypto.PublicKey, payload []byte, sig []byte) bool {
+	digest := sha256.Sum256(payload)
+
+	switch pub := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(pub, payload, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(pub, digest[:], sig)
+	default:
+		return false
+	}
+}
+
+// parsePrivateKey 解析 PKCS8、PKCS1 (RSA) 或 SEC1 (ECDSA) 格式的私钥
+func parsePrivateKey(content string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(content))
+	if block == nil {
+		return nil, errors.New("service private key is not a valid PEM")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if s, ok := key.(crypto.Signer); ok {
+			return s, nil
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("service private key is not a valid RSA, ECDSA or Ed25519 key")
+}
+
+// parsePublicKey 解析 PKIX 或 PKCS1 (RSA) 格式的公钥
+func parsePublicKey(content string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(content))
+	if block == nil {
+		return nil, errors.New("service public key is not a valid PEM")
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("service public key is not a valid RSA, ECDSA or Ed25519 key")
+}
@@ -0,0 +1,168 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+type headerCarrier map[string]string
+
+func (h headerCarrier) Get(key string) string      { return h[key] }
+func (h headerCarrier) Set(key, value string)      { h[key] = value }
+func (h headerCarrier) Add(key, value string)      { h[key] = value }
+func (h headerCarrier) Keys() []string             { return nil }
+func (h headerCarrier) Values(key string) []string { return []string{h[key]} }
+
+type testTransport struct {
+	header headerCarrier
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "/api.order.v1.Order/Get" }
+func (t *testTransport) RequestHeader() transport.Header { return t.header }
+func (t *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+// go test -v -count=1 ./signature -test.run=TestSignAndVerify
+func TestSignAndVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	Setup("order", func() string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}))
+	})
+
+	req := &def.Registry{Name: "order"}
+	tr := &testTransport{header: headerCarrier{}}
+	ctx := transport.NewClientContext(context.Background(), tr)
+	_, err = Client()(func(context.Context, interface{}) (interface{}, error) { return nil, nil })(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "order", tr.header.Get(ServiceHeader))
+
+	conf := &def.Signature{
+		Enable:      true,
+		TrustedKeys: map[string]string{"order": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))},
+		Window:      durationpb.New(time.Minute),
+	}
+	keys := &publicKeys{keys: map[string]crypto.PublicKey{}}
+	nonces := &nonceCache{seen: map[string]time.Time{}}
+	now := time.Now()
+
+	require.NoError(t, verifyRequest(ctx, conf, tr, req, keys, nonces, now))
+	// 重复使用同一个签名
+	require.Error(t, verifyRequest(ctx, conf, tr, req, keys, nonces, now))
+	// 请求体被篡改
+	require.Error(t, verifyRequest(ctx, conf, tr, &def.Registry{Name: "user"}, keys, &nonceCache{seen: map[string]time.Time{}}, now))
+	// 超出时间窗口
+	require.Error(t, verifyRequest(ctx, conf, tr, req, keys, &nonceCache{seen: map[string]time.Time{}}, now.Add(time.Minute*2)))
+	// 不受信任的调用方
+	conf.TrustedKeys = map[string]string{"user": conf.TrustedKeys["order"]}
+	require.Error(t, verifyRequest(ctx, conf, tr, req, keys, &nonceCache{seen: map[string]time.Time{}}, now))
+
+	// 私钥无法解析时返回错误, 不发出未签名的请求
+	Setup("order", func() string { return "invalid key" })
+	defer Setup("", func() string { return "" })
+	called := false
+	tr = &testTransport{header: headerCarrier{}}
+	_, err = Client()(func(context.Context, interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	})(transport.NewClientContext(context.Background(), tr), req)
+	require.Error(t, err)
+	require.False(t, called)
+	require.Empty(t, tr.header.Get(SignatureHeader))
+}
+
+// fakeNonceRedis 通过 go-redis 的 Hook 在内存中实现 SET NX, 不需要连接 Redis
+type fakeNonceRedis struct {
+	lock   sync.Mutex
+	values map[string]time.Duration
+	err    error // 不为空时所有命令返回该错误, 用于模拟 Redis 异常
+}
+
+func (f *fakeNonceRedis) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (f *fakeNonceRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (f *fakeNonceRedis) ProcessHook(redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		if f.err != nil {
+			cmd.SetErr(f.err)
+			return f.err
+		}
+
+		args := cmd.Args()
+		if len(args) < 6 || !strings.EqualFold(fmt.Sprint(args[0]), "set") || !strings.EqualFold(fmt.Sprint(args[5]), "nx") {
+			err := fmt.Errorf("fake redis: unsupported command %v", args)
+			cmd.SetErr(err)
+			return err
+		}
+
+		key := fmt.Sprint(args[1])
+		_, exists := f.values[key]
+		if !exists {
+			ttl, _ := args[4].(int64)
+			f.values[key] = time.Duration(ttl) * time.Second
+		}
+		cmd.(*redis.BoolCmd).SetVal(!exists)
+
+		return nil
+	}
+}
+
+// go test -v -count=1 ./signature -test.run=TestRedisNonces
+func TestRedisNonces(t *testing.T) {
+	fake := &fakeNonceRedis{values: map[string]time.Duration{}}
+	db := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	db.AddHook(fake)
+
+	ctx := context.Background()
+	now := time.Now()
+	newNonces := func() *redisNonces {
+		return &redisNonces{
+			db:     db,
+			prefix: "signature:nonce:user-service:",
+			local:  &nonceCache{seen: map[string]time.Time{}},
+			helper: log.NewHelper(log.DefaultLogger),
+		}
+	}
+	nonces := newNonces()
+
+	// 随机数由所有实例共享, 并在时间窗口结束后过期
+	require.True(t, nonces.add(ctx, "order:n1", now.Add(time.Minute), now))
+	require.False(t, nonces.add(ctx, "order:n1", now.Add(time.Minute), now))
+	require.False(t, newNonces().add(ctx, "order:n1", now.Add(time.Minute), now))
+	require.Equal(t, time.Minute, fake.values["signature:nonce:user-service:order:n1"])
+
+	// Redis 异常时退化为进程内记录
+	fake.err = errors.New("connection refused")
+	require.True(t, nonces.add(ctx, "order:n2", now.Add(time.Minute), now))
+	require.False(t, nonces.add(ctx, "order:n2", now.Add(time.Minute), now))
+}
@@ -0,0 +1,238 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+
+	kit "github.com/eden-quan/go-biz-kit"
+	errorv1 "github.com/eden-quan/go-biz-kit/common/def"
+	"github.com/eden-quan/go-biz-kit/config"
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// DefaultWindow 默认允许的时间偏差
+const DefaultWindow = time.Minute * 5
+
+// ServerParam 为创建签名校验中间件所需的依赖, 注入 Redis 时使用 Redis 记录随机数
+type ServerParam struct {
+	fx.In
+
+	Configuration *def.Configuration
+	Local         *config.LocalConfigure
+	Logger        log.Logger
+	Redis         kit.Redis `optional:"true"` // injection 依赖 client, 无法使用 injection.Optional
+}
+
+// NewServer 创建签名校验中间件, 校验配置通过配置中心 /middleware/signature/config 配置, 并在每次请求时读取,
+// 调用方需要在 trusted_keys 中, 时间戳超出 window 或随机数在 window 内重复使用的请求视为重放,
+// 校验失败时返回 UNAUTHORIZED 错误.
+// 随机数记录在 Redis 中, 由服务的所有实例共享; 未注入 Redis 时只记录在进程内,
+// 此时截获的请求在 window 内仍可以向每个实例各重放一次
+func NewServer(p ServerParam) middleware.Middleware {
+	helper := log.NewHelper(log.With(p.Logger, "module", "signature"))
+	keys := &publicKeys{keys: make(map[string]crypto.PublicKey)}
+
+	var nonces nonceStore = &nonceCache{seen: make(map[string]time.Time)}
+	if p.Redis != nil && !kit.IsDisabled(p.Redis) {
+		nonces = &redisNonces{
+			db:     p.Redis.Get(),
+			prefix: "signature:nonce:" + p.Local.APP.Name + ":",
+			local:  &nonceCache{seen: make(map[string]time.Time)},
+			helper: helper,
+		}
+	} else {
+		helper.Warnw("msg", "redis is not available, signature nonces are only checked within the process")
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			conf := &p.Configuration.Signature
+			if !conf.GetEnable() {
+				return handler(ctx, req)
+			}
+
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok || !requireSignature(conf.GetOperations(), tr.Operation()) {
+				return handler(ctx, req)
+			}
+
+			if err := verifyRequest(ctx, conf, tr, req, keys, nonces, time.Now()); err != nil {
+				helper.Warnw("msg", "verify request signature failed", "operation", tr.Operation(),
+					"service", tr.RequestHeader().Get(ServiceHeader), "err", err)
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}
+	}
+}
+
+func verifyRequest(ctx context.Context, conf *def.Signature, tr transport.Transporter, req interface{}, keys *publicKeys, nonces nonceStore, now time.Time) error {
+	header := tr.RequestHeader()
+	r := &request{
+		operation: tr.Operation(),
+		timestamp: header.Get(TimestampHeader),
+		nonce:     header.Get(NonceHeader),
+		service:   header.Get(ServiceHeader),
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(header.Get(SignatureHeader))
+	if err != nil || len(sig) == 0 || r.nonce == "" {
+		return errorv1.ErrorUnauthorized("request signature is required")
+	}
+
+	content, ok := conf.GetTrustedKeys()[r.service]
+	if !ok {
+		return errorv1.ErrorUnauthorized("service %s is not trusted", r.service)
+	}
+
+	key, err := keys.get(content)
+	if err != nil {
+		return errorv1.ErrorUnauthorized("public key of service %s is invalid: %s", r.service, err)
+	}
+
+	window := DefaultWindow
+	if conf.GetWindow() != nil {
+		window = conf.GetWindow().AsDuration()
+	}
+
+	unix, err := strconv.ParseInt(r.timestamp, 10, 64)
+	if err != nil {
+		return errorv1.ErrorUnauthorized("request signature timestamp is invalid")
+	}
+	signedAt := time.Unix(unix, 0)
+	if signedAt.Before(now.Add(-window)) || signedAt.After(now.Add(window)) {
+		return errorv1.ErrorUnauthorized("request signature is expired")
+	}
+
+	if r.digest, err = bodyDigest(req); err != nil {
+		return errorv1.ErrorUnauthorized("compute request digest failed: %s", err)
+	}
+
+	if !verify(key, r.payload(), sig) {
+		return errorv1.ErrorUnauthorized("request signature is invalid")
+	}
+
+	// 签名校验通过后才记录随机数, 避免伪造的请求占用缓存
+	if !nonces.add(ctx, r.service+":"+r.nonce, signedAt.Add(window), now) {
+		return errorv1.ErrorUnauthorized("request signature is replayed")
+	}
+
+	return nil
+}
+
+// requireSignature 判断接口是否需要校验签名, operations 为空时所有接口都需要校验
+func requireSignature(operations []string, operation string) bool {
+	if len(operations) == 0 {
+		return true
+	}
+
+	for _, pattern := range operations {
+		if pattern == "*" || pattern == operation ||
+			(strings.HasSuffix(pattern, "*") && strings.HasPrefix(operation, strings.TrimSuffix(pattern, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publicKeys 缓存解析后的公钥
+type publicKeys struct {
+	lock sync.Mutex
+	keys map[string]crypto.PublicKey
+}
+
+func (k *publicKeys) get(content string) (crypto.PublicKey, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if key, ok := k.keys[content]; ok {
+		return key, nil
+	}
+
+	key, err := parsePublicKey(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// 公钥轮换后旧的公钥不再使用, 超过一定数量时清空缓存
+	if len(k.keys) >= 64 {
+		k.keys = make(map[string]crypto.PublicKey)
+	}
+	k.keys[content] = key
+
+	return key, nil
+}
+
+// nonceStore 记录时间窗口内使用过的随机数
+type nonceStore interface {
+	// add 记录随机数, 随机数在过期前已被使用时返回 false
+	add(ctx context.Context, nonce string, expireAt time.Time, now time.Time) bool
+}
+
+// nonceCache 在进程内记录时间窗口内使用过的随机数
+type nonceCache struct {
+	lock     sync.Mutex
+	seen     map[string]time.Time
+	prunedAt time.Time
+}
+
+// add 记录随机数, 随机数在过期前已被使用时返回 false
+func (c *nonceCache) add(_ context.Context, nonce string, expireAt time.Time, now time.Time) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if now.Sub(c.prunedAt) > time.Second*10 {
+		for key, expire := range c.seen {
+			if expire.Before(now) {
+				delete(c.seen, key)
+			}
+		}
+		c.prunedAt = now
+	}
+
+	if expire, ok := c.seen[nonce]; ok && !expire.Before(now) {
+		return false
+	}
+
+	c.seen[nonce] = expireAt
+	return true
+}
+
+// redisNonces 在 Redis 中记录使用过的随机数, 由服务的所有实例共享, Redis 异常时退化为进程内记录
+type redisNonces struct {
+	db     redis.UniversalClient
+	prefix string
+	local  *nonceCache
+	helper *log.Helper
+}
+
+// add 记录随机数, 随机数在过期前已被使用时返回 false
+func (r *redisNonces) add(ctx context.Context, nonce string, expireAt time.Time, now time.Time) bool {
+	// 过期时间为 0 时 Redis 不会过期, 至少保留一秒
+	ttl := expireAt.Sub(now)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+
+	added, err := r.db.SetNX(ctx, r.prefix+nonce, 1, ttl).Result()
+	if err != nil {
+		// Redis 异常时不拒绝请求, 避免签名组件影响业务的可用性
+		r.helper.Errorw("msg", "record signature nonce in redis failed", "err", err)
+		return r.local.add(ctx, nonce, expireAt, now)
+	}
+
+	return added
+}
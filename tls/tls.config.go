@@ -0,0 +1,141 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// ServerConfig 根据服务的 TLS 配置创建服务端的 tls.Config, 未启用 TLS 时返回 nil, nextProtos 为服务支持的 ALPN 协议,
+// 证书及 CA 在握手时按需重新加载, 配置了 require_client_cert 时要求客户端提供由 CA 签发的证书
+func ServerConfig(conf func() *def.TLS, logger log.Logger, nextProtos ...string) (*tls.Config, error) {
+	if !conf().GetEnable() {
+		return nil, nil
+	}
+
+	loader, err := NewLoader(conf, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if loader.Certificate() == nil {
+		return nil, errors.New("tls is enabled but server certificate is not configured")
+	}
+
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			if cert := loader.Certificate(); cert != nil {
+				return cert, nil
+			}
+			return nil, errors.New("tls server certificate is not configured")
+		},
+	}
+
+	// 每次握手使用最新的 CA 及客户端证书要求, 其他配置 (如 net/http 追加的 NextProtos) 从 base 复制
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		cfg.ClientCAs = loader.CertPool()
+
+		if conf().GetRequireClientCert() {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		} else if cfg.ClientCAs != nil {
+			cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+
+		return cfg, nil
+	}
+
+	return base, nil
+}
+
+// ClientConfig 根据目标服务的 TLS 配置创建客户端的 tls.Config, 未启用 TLS 时返回 nil,
+// 配置了证书时用于 mTLS, 服务端证书使用最新的 CA 校验, 未配置 CA 时使用系统 CA,
+// 服务端证书使用 server_name 校验, 未配置时使用 address 返回的连接地址中的域名或 IP, 两者都无法确定时返回错误
+func ClientConfig(conf func() *def.TLS, address func() string, logger log.Logger) (*tls.Config, error) {
+	if !conf().GetEnable() {
+		return nil, nil
+	}
+
+	serverName := func() string {
+		if name := conf().GetServerName(); name != "" {
+			return name
+		}
+		return hostOf(address())
+	}
+	if serverName() == "" {
+		return nil, errors.New("tls server_name is required when the target address is unknown, such as using discovery")
+	}
+
+	loader, err := NewLoader(conf, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: conf().GetServerName(),
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert := loader.Certificate(); cert != nil {
+				return cert, nil
+			}
+			// 未配置客户端证书时不发送证书
+			return &tls.Certificate{}, nil
+		},
+		// RootCAs 无法在创建后更新, 因此跳过默认校验, 由 VerifyConnection 使用最新的 CA 校验服务端证书
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return verifyServer(cs, serverName(), loader.CertPool())
+		},
+	}, nil
+}
+
+// verifyServer 使用 roots 校验服务端证书, 与 tls 的默认校验逻辑一致, serverName 为 IP 时校验证书中的 IP
+func verifyServer(cs tls.ConnectionState, serverName string, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server did not provide a certificate")
+	}
+
+	// 不能使用空的 serverName 校验, 否则任意由 CA 签发的证书都会通过
+	if serverName == "" {
+		return errors.New("tls: server name is unknown, unable to verify the server certificate")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// hostOf 返回地址中的域名或 IP, address 格式与 Registry.address 相同, 如 127.0.0.1:8000 或 http://user-service:8000
+func hostOf(address string) string {
+	if address == "" {
+		return ""
+	}
+
+	if !strings.Contains(address, "://") {
+		address = "//" + address
+	}
+
+	target, err := url.Parse(address)
+	if err != nil {
+		return ""
+	}
+
+	return target.Hostname()
+}
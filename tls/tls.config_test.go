@@ -0,0 +1,154 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))}
+}
+
+// issue 签发证书, hosts 中的 IP 写入 IP SAN, 其余写入 DNS SAN
+func (ca *testCA) issue(t *testing.T, hosts ...string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: hosts[0]},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+// handshake 通过本地 TCP 连接完成一次握手, 返回客户端的连接状态及双方的错误
+func handshake(t *testing.T, server *tls.Config, client *tls.Config) (tls.ConnectionState, error, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		raw, err := ln.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		conn := tls.Server(raw, server)
+		serverErr <- conn.Handshake()
+		_ = conn.Close()
+	}()
+
+	raw, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = raw.Close() }()
+
+	conn := tls.Client(raw, client)
+	clientErr := conn.Handshake()
+	state := conn.ConnectionState()
+	_ = conn.Close()
+
+	return state, clientErr, <-serverErr
+}
+
+// go test -v -count=1 ./tls -test.run=TestHandshake
+func TestHandshake(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.issue(t, "user-service", "127.0.0.1")
+	clientCert, clientKey := ca.issue(t, "order-service")
+
+	serverConf := &def.TLS{Enable: true, CertPem: serverCert, KeyPem: serverKey, CaPem: ca.pem, RequireClientCert: true}
+	server, err := ServerConfig(func() *def.TLS { return serverConf }, log.DefaultLogger, "http/1.1")
+	require.NoError(t, err)
+	// 与 net/http 启用 HTTP/2 时的处理一致, 握手时使用的配置需要包含之后追加的协议
+	server.NextProtos = append([]string{"h2"}, server.NextProtos...)
+
+	newClient := func(conf *def.TLS, address string) *tls.Config {
+		client, err := ClientConfig(func() *def.TLS { return conf }, func() string { return address }, log.DefaultLogger)
+		require.NoError(t, err)
+		client.NextProtos = []string{"h2"}
+		return client
+	}
+	clientConf := &def.TLS{Enable: true, CertPem: clientCert, KeyPem: clientKey, CaPem: ca.pem}
+
+	// 使用 IP 地址连接时校验证书中的 IP, 并协商出 h2
+	state, clientErr, serverErr := handshake(t, server, newClient(clientConf, "127.0.0.1:8000"))
+	require.NoError(t, clientErr)
+	require.NoError(t, serverErr)
+	require.Equal(t, "h2", state.NegotiatedProtocol)
+
+	// 使用域名连接
+	_, clientErr, serverErr = handshake(t, server, newClient(clientConf, "http://user-service:8000"))
+	require.NoError(t, clientErr)
+	require.NoError(t, serverErr)
+
+	// 证书不属于连接的地址时拒绝
+	_, clientErr, _ = handshake(t, server, newClient(clientConf, "10.0.0.1:8000"))
+	require.Error(t, clientErr)
+
+	other := &def.TLS{Enable: true, CertPem: clientCert, KeyPem: clientKey, CaPem: ca.pem, ServerName: "payment-service"}
+	_, clientErr, _ = handshake(t, server, newClient(other, "127.0.0.1:8000"))
+	require.Error(t, clientErr)
+
+	// 服务端要求客户端证书
+	_, _, serverErr = handshake(t, server, newClient(&def.TLS{Enable: true, CaPem: ca.pem}, "127.0.0.1:8000"))
+	require.Error(t, serverErr)
+
+	// 无法确定校验使用的域名时拒绝创建
+	_, err = ClientConfig(func() *def.TLS { return clientConf }, func() string { return "" }, log.DefaultLogger)
+	require.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// 检查证书是否变化的最小间隔, 避免每次握手都读取文件信息
+const checkInterval = time.Second
+
+// Loader 根据 TLS 配置加载证书及 CA, 在握手时检查配置及证书文件是否变化, 变化后重新加载, 加载失败时继续使用之前的证书
+type Loader struct {
+	conf   func() *def.TLS
+	logger *log.Helper
+
+	lock      sync.Mutex
+	loaded    *def.TLS  // 上次加载时的配置
+	stamp     string    // 上次加载时证书文件的修改时间及大小
+	checkedAt time.Time // 上次检查的时间
+	cert      *tls.Certificate
+	pool      *x509.CertPool
+}
+
+// NewLoader 创建证书加载器, conf 返回当前的 TLS 配置, 首次加载失败时返回错误
+func NewLoader(conf func() *def.TLS, logger log.Logger) (*Loader, error) {
+	l := &Loader{
+		conf:   conf,
+		logger: log.NewHelper(log.With(logger, "module", "tls")),
+	}
+
+	if err := l.reload(conf()); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Certificate 返回当前的证书, 未配置证书时返回 nil
+func (l *Loader) Certificate() *tls.Certificate {
+	l.refresh()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.cert
+}
+
+// CertPool 返回当前的 CA, 未配置 CA 时返回 nil
+func (l *Loader) CertPool() *x509.CertPool {
+	l.refresh()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.pool
+}
+
+// refresh 在配置或证书文件变化时重新加载
+func (l *Loader) refresh() {
+	l.lock.Lock()
+	if time.Since(l.checkedAt) < checkInterval {
+		l.lock.Unlock()
+		return
+	}
+	l.checkedAt = time.Now()
+	conf := proto.Clone(l.conf()).(*def.TLS)
+	changed := !proto.Equal(conf, l.loaded) || fileStamp(conf) != l.stamp
+	l.lock.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if err := l.reload(conf); err != nil {
+		l.logger.Errorf("reload tls certificate failed, keep using the previous one: %s", err)
+		return
+	}
+
+	l.logger.Info("tls certificate reloaded")
+}
+
+// reload 加载证书及 CA
+func (l *Loader) reload(conf *def.TLS) error {
+	stamp := fileStamp(conf)
+
+	var cert *tls.Certificate
+	certPEM, err := readPEM(conf.GetCertPem(), conf.GetCertFile())
+	if err != nil {
+		return err
+	}
+	keyPEM, err := readPEM(conf.GetKeyPem(), conf.GetKeyFile())
+	if err != nil {
+		return err
+	}
+	if len(certPEM) != 0 || len(keyPEM) != 0 {
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("load tls certificate failed with error %w", err)
+		}
+		cert = &pair
+	}
+
+	var pool *x509.CertPool
+	caPEM, err := readPEM(conf.GetCaPem(), conf.GetCaFile())
+	if err != nil {
+		return err
+	}
+	if len(caPEM) != 0 {
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return errors.New("load tls ca failed, no valid certificate found")
+		}
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.loaded = proto.Clone(conf).(*def.TLS)
+	l.stamp = stamp
+	l.cert = cert
+	l.pool = pool
+
+	return nil
+}
+
+// readPEM 优先返回配置中的 PEM 内容, 未配置时读取文件
+func readPEM(content string, file string) ([]byte, error) {
+	if content != "" {
+		return []byte(content), nil
+	}
+
+	if file == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read tls file %s failed with error %w", file, err)
+	}
+
+	return data, nil
+}
+
+// fileStamp 返回配置中证书文件的修改时间及大小, 用于判断文件是否变化
+func fileStamp(conf *def.TLS) string {
+	stamp := ""
+	for _, file := range []string{conf.GetCertFile(), conf.GetKeyFile(), conf.GetCaFile()} {
+		if file == "" {
+			continue
+		}
+
+		if info, err := os.Stat(file); err == nil {
+			stamp += fmt.Sprintf("%s:%d:%d;", file, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+
+	return stamp
+}
@@ -0,0 +1,80 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eden-quan/go-biz-kit/config/def"
+)
+
+// newCert 生成自签名证书, 返回 PEM 格式的证书及私钥
+func newCert(t *testing.T, name string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+// go test -v -count=1 ./tls -test.run=TestLoaderReload
+func TestLoaderReload(t *testing.T) {
+	dir := t.TempDir()
+	conf := &def.TLS{
+		Enable:   true,
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+	}
+
+	writeCert := func(name string) {
+		cert, key := newCert(t, name)
+		require.NoError(t, os.WriteFile(conf.CertFile, cert, 0600))
+		require.NoError(t, os.WriteFile(conf.KeyFile, key, 0600))
+	}
+
+	writeCert("first.local")
+	loader, err := NewLoader(func() *def.TLS { return conf }, log.DefaultLogger)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(loader.Certificate().Certificate[0])
+	require.NoError(t, err)
+	require.Equal(t, "first.local", leaf.Subject.CommonName)
+
+	// 证书文件更新后重新加载
+	writeCert("second.local")
+	loader.checkedAt = time.Time{}
+	leaf, err = x509.ParseCertificate(loader.Certificate().Certificate[0])
+	require.NoError(t, err)
+	require.Equal(t, "second.local", leaf.Subject.CommonName)
+
+	// 配置错误时继续使用之前的证书
+	conf.KeyPem = "invalid"
+	loader.checkedAt = time.Time{}
+	leaf, err = x509.ParseCertificate(loader.Certificate().Certificate[0])
+	require.NoError(t, err)
+	require.Equal(t, "second.local", leaf.Subject.CommonName)
+}
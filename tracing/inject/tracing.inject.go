@@ -19,3 +19,9 @@ func InjectIns(inj *injection.Injector) {
 			tracing.InitTracing,
 		))
 }
+
+// Module 链路跟踪模块 tracinginit, 在启动时初始化全局的链路跟踪, 用于不安装 setup 模块的场景,
+// 与 setup 提供的 tracing 模块 (同时在退出时上报缓存的链路数据) 使用不同的名称, 两者同时安装时只会初始化一次
+func Module() *injection.Module {
+	return injection.NewModule("tracinginit").Require("config", "logger").With(InjectIns)
+}
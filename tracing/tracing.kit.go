@@ -74,3 +74,12 @@ func InitTracing(conf *def.Configuration, logger log.Logger, local *config.Local
 
 	return &TracerInitializer{}, err
 }
+
+// Shutdown 上报缓存中的链路数据后关闭全局链路跟踪器, 一般在服务退出时调用
+func Shutdown(ctx context.Context) error {
+	if tp, ok := otel.GetTracerProvider().(*trace.TracerProvider); ok {
+		return tp.Shutdown(ctx)
+	}
+
+	return nil
+}